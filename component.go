@@ -39,6 +39,17 @@ type Groupable interface {
 	Current() Focusable
 }
 
+// Container extends the Focusable interface for elements holding
+// other focusable elements, allowing the whole tree of focusables to
+// be walked.
+type Container interface {
+	Focusable
+
+	// Children returns the focusable elements currently reachable
+	// within the container.
+	Children() []Focusable
+}
+
 // Tabbable extends the Groupable interface, adding methods for
 // managing tab navigation and active state.
 //
@@ -53,3 +64,29 @@ type Tabbable interface {
 	// Active returns true if the tab is currently active.
 	Active() bool
 }
+
+// TabState is the status of a tab, shown next to its title.
+type TabState int
+
+const (
+	TabNormal TabState = iota
+	TabWarning
+	TabError
+	TabBusy
+)
+
+// Decorated extends the Tabbable interface for tabs whose title is
+// decorated with an icon, a badge (e.g. an unread count) and a state
+// marker.
+type Decorated interface {
+	Tabbable
+
+	// Icon returns the icon shown before the title, if any.
+	Icon() string
+
+	// Badge returns the text shown after the title, if any.
+	Badge() string
+
+	// State returns the status of the tab.
+	State() TabState
+}
@@ -0,0 +1,446 @@
+package dialog
+
+// Package dialog provides a modal dialog rendered over the
+// application through an overlay. A dialog shows a title, a body and
+// a row of buttons. While it is open it traps the input messages:
+// the application should give it the chance to handle every message
+// first and stop there if the dialog captured it, e.g.
+//
+//	if m.dialog.Captures(msg) {
+//		_, cmd := m.dialog.Update(msg)
+//		return m, cmd
+//	}
+//
+// When the dialog is dismissed a ResultMsg is emitted.
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/remogatto/sugarfoam/components/header"
+	"github.com/remogatto/sugarfoam/components/textinput"
+	"github.com/remogatto/sugarfoam/layout"
+	"github.com/remogatto/sugarfoam/layout/overlay"
+)
+
+// DefaultWidth defines the default width of a dialog.
+var DefaultWidth = 50
+
+// ResultMsg is emitted when a dialog is dismissed. Button is the
+// label of the chosen button, or the cancel button if the dialog was
+// cancelled. Value holds the value of the body, if it has one (e.g.
+// the text entered in a prompt).
+type ResultMsg struct {
+	ID     string
+	Button string
+	Value  string
+}
+
+// Option is a type for functions that modify a dialog model.
+type Option func(*Model)
+
+// KeyMap defines the key bindings of the dialog.
+type KeyMap struct {
+	NextButton key.Binding
+	PrevButton key.Binding
+	Confirm    key.Binding
+	Cancel     key.Binding
+}
+
+// DefaultKeyMap returns the default key bindings of the dialog.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		NextButton: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "next button"),
+		),
+		PrevButton: key.NewBinding(
+			key.WithKeys("shift+tab"),
+			key.WithHelp("shift+tab", "prev button"),
+		),
+		Confirm: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "confirm"),
+		),
+		Cancel: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel"),
+		),
+	}
+}
+
+// Styles defines the styles of the dialog.
+type Styles struct {
+	Dialog       lipgloss.Style
+	Title        lipgloss.Style
+	Button       lipgloss.Style
+	ActiveButton lipgloss.Style
+}
+
+// DefaultStyles returns the default styles of the dialog.
+func DefaultStyles() *Styles {
+	return &Styles{
+		Dialog: lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("5")).
+			Padding(1, 2),
+		Title: lipgloss.NewStyle().Bold(true).MarginBottom(1),
+		Button: lipgloss.NewStyle().
+			Background(lipgloss.Color("#373B41")).
+			Foreground(lipgloss.Color("240")).
+			Padding(0, 2).
+			MarginLeft(1),
+		ActiveButton: lipgloss.NewStyle().
+			Background(lipgloss.Color("5")).
+			Foreground(lipgloss.Color("#ffffff")).
+			Padding(0, 2).
+			MarginLeft(1),
+	}
+}
+
+// Model represents a modal dialog.
+type Model struct {
+	KeyMap KeyMap
+
+	id, title string
+	body      layout.Placeable
+
+	buttons       []string
+	cancel        string
+	currButton    int
+	width, height int
+
+	opened   bool
+	overlay  *overlay.Model
+	position overlay.Position
+	styles   *Styles
+}
+
+// New creates a new dialog with optional configurations.
+func New(opts ...Option) *Model {
+	m := &Model{
+		KeyMap:   DefaultKeyMap(),
+		buttons:  []string{"OK"},
+		width:    DefaultWidth,
+		position: overlay.Center(),
+		styles:   DefaultStyles(),
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	if m.cancel == "" && len(m.buttons) > 0 {
+		m.cancel = m.buttons[len(m.buttons)-1]
+	}
+
+	return m
+}
+
+// Alert creates a dialog showing text with a single OK button.
+func Alert(id, title, text string, opts ...Option) *Model {
+	opts = append([]Option{
+		WithID(id),
+		WithTitle(title),
+		WithText(text),
+		WithButtons("OK"),
+		WithCancel("OK"),
+	}, opts...)
+
+	return New(opts...)
+}
+
+// Confirm creates a dialog asking to confirm text, with OK and Cancel
+// buttons.
+func Confirm(id, title, text string, opts ...Option) *Model {
+	opts = append([]Option{
+		WithID(id),
+		WithTitle(title),
+		WithText(text),
+		WithButtons("OK", "Cancel"),
+		WithCancel("Cancel"),
+	}, opts...)
+
+	return New(opts...)
+}
+
+// Prompt creates a dialog asking for a line of text, with OK and
+// Cancel buttons. The entered text is reported in the Value field of
+// the ResultMsg.
+func Prompt(id, title, placeholder string, opts ...Option) *Model {
+	opts = append([]Option{
+		WithID(id),
+		WithTitle(title),
+		WithBody(textinput.New(textinput.WithPlaceholder(placeholder))),
+		WithButtons("OK", "Cancel"),
+		WithCancel("Cancel"),
+	}, opts...)
+
+	return New(opts...)
+}
+
+// WithID sets the identifier reported in the ResultMsg.
+func WithID(id string) Option {
+	return func(m *Model) {
+		m.id = id
+	}
+}
+
+// WithTitle sets the title of the dialog.
+func WithTitle(title string) Option {
+	return func(m *Model) {
+		m.title = title
+	}
+}
+
+// WithBody sets the body of the dialog. If the body, or the item it
+// wraps (see layout.Unwrap), is a tea.Model it receives the messages
+// handled by the dialog, and if it's focusable it's focused when the
+// dialog opens.
+func WithBody(body layout.Placeable) Option {
+	return func(m *Model) {
+		m.body = body
+	}
+}
+
+// WithText sets a text body.
+func WithText(text string) Option {
+	return func(m *Model) {
+		m.body = header.New(header.WithContent(text))
+	}
+}
+
+// WithButtons sets the labels of the buttons, from left to right.
+func WithButtons(buttons ...string) Option {
+	return func(m *Model) {
+		m.buttons = buttons
+	}
+}
+
+// WithCancel sets the button reported when the dialog is cancelled.
+// It is the last button by default.
+func WithCancel(button string) Option {
+	return func(m *Model) {
+		m.cancel = button
+	}
+}
+
+// WithOverlay sets the overlay the dialog is drawn on when opened.
+func WithOverlay(o *overlay.Model) Option {
+	return func(m *Model) {
+		m.overlay = o
+	}
+}
+
+// WithPosition sets the position of the dialog on the overlay. The
+// dialog is centered by default.
+func WithPosition(position overlay.Position) Option {
+	return func(m *Model) {
+		m.position = position
+	}
+}
+
+// WithWidth sets the width of the dialog.
+func WithWidth(width int) Option {
+	return func(m *Model) {
+		m.width = width
+	}
+}
+
+// WithKeyMap sets the key bindings of the dialog.
+func WithKeyMap(km KeyMap) Option {
+	return func(m *Model) {
+		m.KeyMap = km
+	}
+}
+
+// WithStyles sets the styles of the dialog.
+func WithStyles(styles *Styles) Option {
+	return func(m *Model) {
+		m.styles = styles
+	}
+}
+
+// Open shows the dialog on its overlay and focuses its body.
+func (m *Model) Open() tea.Cmd {
+	if m.opened {
+		return nil
+	}
+
+	m.opened = true
+	m.currButton = 0
+	m.SetWidth(m.width)
+
+	if m.overlay != nil {
+		m.overlay.Push(m, m.position)
+	}
+
+	return m.Focus()
+}
+
+// Close hides the dialog without emitting a ResultMsg.
+func (m *Model) Close() {
+	if !m.opened {
+		return
+	}
+
+	m.opened = false
+	m.Blur()
+
+	if m.overlay != nil {
+		m.overlay.Remove(m)
+	}
+}
+
+// Opened returns true if the dialog is open.
+func (m *Model) Opened() bool {
+	return m.opened
+}
+
+// Captures returns true if msg must not reach the components under
+// the dialog, i.e. if the dialog is open and msg is an input message.
+func (m *Model) Captures(msg tea.Msg) bool {
+	if !m.opened {
+		return false
+	}
+
+	switch msg.(type) {
+	case tea.KeyMsg, tea.MouseMsg:
+		return true
+	}
+
+	return false
+}
+
+// Focus focuses the body of the dialog, if it's focusable.
+func (m *Model) Focus() tea.Cmd {
+	if f, ok := layout.Unwrap(m.body).(interface{ Focus() tea.Cmd }); ok {
+		return f.Focus()
+	}
+	return nil
+}
+
+// Blur blurs the body of the dialog, if it's focusable.
+func (m *Model) Blur() {
+	if f, ok := layout.Unwrap(m.body).(interface{ Blur() }); ok {
+		f.Blur()
+	}
+}
+
+// Init initializes the body of the dialog.
+func (m *Model) Init() tea.Cmd {
+	if b, ok := layout.Unwrap(m.body).(tea.Model); ok {
+		return b.Init()
+	}
+	return nil
+}
+
+// Update handles the key bindings of the dialog while it's open and
+// forwards the other messages to its body.
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if !m.opened {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if len(m.buttons) == 0 {
+			break
+		}
+
+		switch {
+		case key.Matches(msg, m.KeyMap.NextButton):
+			m.currButton = (m.currButton + 1) % len(m.buttons)
+			return m, nil
+
+		case key.Matches(msg, m.KeyMap.PrevButton):
+			m.currButton--
+			if m.currButton < 0 {
+				m.currButton = len(m.buttons) - 1
+			}
+			return m, nil
+
+		case key.Matches(msg, m.KeyMap.Confirm):
+			return m, m.dismiss(m.buttons[m.currButton])
+
+		case key.Matches(msg, m.KeyMap.Cancel):
+			return m, m.dismiss(m.cancel)
+		}
+	}
+
+	if b, ok := layout.Unwrap(m.body).(tea.Model); ok {
+		_, cmd := b.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+// View renders the dialog.
+func (m *Model) View() string {
+	w := m.width - m.styles.Dialog.GetHorizontalFrameSize()
+
+	buttons := make([]string, 0)
+
+	for i, b := range m.buttons {
+		if i == m.currButton {
+			buttons = append(buttons, m.styles.ActiveButton.Render(b))
+		} else {
+			buttons = append(buttons, m.styles.Button.Render(b))
+		}
+	}
+
+	views := make([]string, 0)
+
+	if m.title != "" {
+		views = append(views, m.styles.Title.Render(m.title))
+	}
+	if m.body != nil {
+		views = append(views, m.body.View(), "")
+	}
+	views = append(views, lipgloss.PlaceHorizontal(w, lipgloss.Right, lipgloss.JoinHorizontal(lipgloss.Top, buttons...)))
+
+	return m.styles.Dialog.Width(w + m.styles.Dialog.GetHorizontalPadding()).Render(lipgloss.JoinVertical(lipgloss.Left, views...))
+}
+
+// SetWidth sets the width of the dialog and of its body.
+func (m *Model) SetWidth(width int) {
+	m.width = width
+
+	if m.body != nil {
+		m.body.SetWidth(width - m.styles.Dialog.GetHorizontalFrameSize())
+	}
+}
+
+// SetHeight sets the height of the dialog. The actual height depends
+// on its content.
+func (m *Model) SetHeight(height int) { m.height = height }
+
+// SetSize sets the dimensions of the dialog.
+func (m *Model) SetSize(width int, height int) {
+	m.SetWidth(width)
+	m.SetHeight(height)
+}
+
+// GetWidth returns the current width of the dialog.
+func (m *Model) GetWidth() int { return m.width }
+
+// GetHeight returns the current height of the dialog.
+func (m *Model) GetHeight() int { return lipgloss.Height(m.View()) }
+
+func (m *Model) CanGrow() bool {
+	return false
+}
+
+func (m *Model) dismiss(button string) tea.Cmd {
+	result := ResultMsg{ID: m.id, Button: button}
+
+	if v, ok := layout.Unwrap(m.body).(interface{ Value() string }); ok {
+		result.Value = v.Value()
+	}
+
+	m.Close()
+
+	return func() tea.Msg {
+		return result
+	}
+}
@@ -0,0 +1,24 @@
+package dialog
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCancelDefault(t *testing.T) {
+	d := New(WithID("quit"), WithButtons("Quit", "Stay"))
+	d.Open()
+
+	_, cmd := d.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd == nil {
+		t.Fatal("esc didn't dismiss the dialog")
+	}
+
+	if msg, ok := cmd().(ResultMsg); !ok || msg.Button != "Stay" {
+		t.Errorf("esc reported %v, want the last button", msg)
+	}
+	if d.Opened() {
+		t.Error("the dialog is still open")
+	}
+}
@@ -19,12 +19,16 @@ type Model struct {
 
 	focused   bool
 	currFocus int
+	router    foam.Router
+
+	x, y int
 }
 
 func New(opts ...Option) *Model {
 	group := new(Model)
 
 	group.KeyMap = DefaultKeyMap()
+	group.router = foam.DefaultRouter
 	group.SetStyles(foam.DefaultStyles())
 
 	for _, opt := range opts {
@@ -54,14 +58,36 @@ func DefaultKeyMap() KeyMap {
 func (g *Model) SetSize(width int, height int) {
 	g.Common.SetSize(width, height)
 	g.layout.SetSize(g.GetWidth(), g.GetHeight())
+
+	layout.PlaceChildren(g, g.x, g.y)
 }
 
 func (g *Model) Current() foam.Focusable {
 	return g.items[g.currFocus]
 }
 
+func (g *Model) Items() []foam.Focusable {
+	return g.items
+}
+
+func (g *Model) Children() []foam.Focusable {
+	return g.items
+}
+
+// SetCurrent makes item the current element of the group, without
+// changing the focus state of the elements.
+func (g *Model) SetCurrent(item foam.Focusable) {
+	for i, it := range g.items {
+		if it == item {
+			g.currFocus = i
+			return
+		}
+	}
+}
+
 func (g *Model) Blur() {
 	g.focused = false
+	g.Current().Blur()
 }
 
 func (g *Model) Focus() tea.Cmd {
@@ -88,13 +114,13 @@ func (g *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msg, g.KeyMap.FocusNext):
-			cmds = append(cmds, g.nextFocus())
+			return g, g.nextFocus()
 		case key.Matches(msg, g.KeyMap.FocusPrev):
-			cmds = append(cmds, g.prevFocus())
+			return g, g.prevFocus()
 		}
 	}
 
-	cmds = append(cmds, g.updateComponents(msg)...)
+	cmds = append(cmds, g.dispatch(msg)...)
 
 	return g, tea.Batch(cmds...)
 }
@@ -103,6 +129,31 @@ func (g *Model) View() string {
 	return g.layout.View()
 }
 
+// Placements returns the layout of the group, which takes the whole
+// group area.
+func (g *Model) Placements() []layout.Placement {
+	return []layout.Placement{
+		{Item: g.layout, Rect: layout.Rect{X: 0, Y: 0, W: g.GetWidth(), H: g.GetHeight()}},
+	}
+}
+
+// SetOrigin moves the group to the absolute position x, y.
+func (g *Model) SetOrigin(x, y int) {
+	g.x, g.y = x, y
+	layout.PlaceChildren(g, x, y)
+}
+
+// Origin returns the absolute position of the group.
+func (g *Model) Origin() (int, int) {
+	return g.x, g.y
+}
+
+// HitTest returns the innermost element drawn at the absolute
+// position x, y, or nil if there is none.
+func (g *Model) HitTest(x, y int) layout.Placeable {
+	return layout.HitTest(g, x-g.x, y-g.y)
+}
+
 func (m *Model) CanGrow() bool {
 	return true
 }
@@ -119,6 +170,15 @@ func WithItems(items ...foam.Focusable) Option {
 	}
 }
 
+// WithRouter sets the router deciding which items receive each
+// message. Key messages go to the current item and mouse messages to
+// the item under the pointer by default (see foam.DefaultRouter).
+func WithRouter(router foam.Router) Option {
+	return func(g *Model) {
+		g.router = router
+	}
+}
+
 func WithLayout(layout *layout.Layout) Option {
 	return func(m *Model) {
 		m.layout = layout
@@ -128,6 +188,14 @@ func WithLayout(layout *layout.Layout) Option {
 func (g *Model) nextFocus() tea.Cmd {
 	g.Current().Blur()
 
+	g.currFocus = (g.currFocus + 1) % len(g.items)
+
+	return g.Current().Focus()
+}
+
+func (g *Model) prevFocus() tea.Cmd {
+	g.Current().Blur()
+
 	g.currFocus--
 	if g.currFocus < 0 {
 		g.currFocus = len(g.items) - 1
@@ -136,12 +204,44 @@ func (g *Model) nextFocus() tea.Cmd {
 	return g.Current().Focus()
 }
 
-func (g *Model) prevFocus() tea.Cmd {
-	g.Current().Blur()
+// handleMouse dispatches msg to the item under the pointer only,
+// focusing it on click.
+func (g *Model) handleMouse(msg tea.MouseMsg) tea.Cmd {
+	for i, item := range g.items {
+		r, ok := layout.Locate(g.layout, item)
+		if !ok || !r.Contains(msg.X, msg.Y) {
+			continue
+		}
 
-	g.currFocus = (g.currFocus - 1) % len(g.items)
+		var cmds []tea.Cmd
 
-	return g.Current().Focus()
+		if foam.IsClick(msg) && i != g.currFocus {
+			g.Current().Blur()
+			g.currFocus = i
+			cmds = append(cmds, g.Current().Focus())
+		}
+
+		_, cmd := item.Update(foam.LocalMouse(msg, r))
+
+		return tea.Batch(append(cmds, cmd)...)
+	}
+
+	return nil
+}
+
+func (g *Model) dispatch(msg tea.Msg) []tea.Cmd {
+	switch g.router(msg) {
+	case foam.Pointer:
+		if msg, ok := msg.(tea.MouseMsg); ok {
+			return []tea.Cmd{g.handleMouse(msg)}
+		}
+		fallthrough
+	case foam.Focused:
+		_, cmd := g.Current().Update(msg)
+		return []tea.Cmd{cmd}
+	}
+
+	return g.updateComponents(msg)
 }
 
 func (g *Model) updateComponents(msg tea.Msg) []tea.Cmd {
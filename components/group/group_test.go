@@ -0,0 +1,42 @@
+package group
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/remogatto/sugarfoam/internal/foamtest"
+	"github.com/remogatto/sugarfoam/layout"
+)
+
+func TestFocusNextNested(t *testing.T) {
+	a, b, c := foamtest.NewField("a"), foamtest.NewField("b"), foamtest.NewField("c")
+
+	inner := New(
+		WithItems(b, c),
+		WithLayout(layout.New(layout.WithStyles(&layout.Styles{}), layout.WithItem(b), layout.WithItem(c))),
+	)
+	outer := New(
+		WithItems(a, inner),
+		WithLayout(layout.New(layout.WithStyles(&layout.Styles{}), layout.WithItem(a), layout.WithItem(inner))),
+	)
+	outer.Init()
+	outer.SetSize(20, 10)
+
+	outer.Update(tea.KeyMsg{Type: tea.KeyTab})
+
+	if outer.Current() != inner || inner.Current() != b {
+		t.Fatalf("the tab key focused %v, want b", inner.Current())
+	}
+	if !b.HasFocus || c.HasFocus || a.HasFocus {
+		t.Errorf("focus a=%v b=%v c=%v, want only b focused", a.HasFocus, b.HasFocus, c.HasFocus)
+	}
+	if len(b.Msgs) != 0 {
+		t.Errorf("b received %v, want no messages", b.Msgs)
+	}
+
+	outer.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+
+	if outer.Current() != a || !a.HasFocus || b.HasFocus {
+		t.Errorf("the shift+tab key focused %v, want a", outer.Current())
+	}
+}
@@ -0,0 +1,51 @@
+package palette
+
+import (
+	"unicode"
+)
+
+// Match reports whether all the runes of pattern appear in text in
+// the same order, ignoring case, and returns a score measuring the
+// quality of the match. Matches on consecutive runes and at the
+// beginning of words score higher, while gaps lower the score. An
+// empty pattern matches everything with a score of zero.
+func Match(pattern, text string) (int, bool) {
+	p := []rune(pattern)
+	t := []rune(text)
+
+	score := 0
+	prev := -1
+	j := 0
+
+	for i := 0; i < len(t) && j < len(p); i++ {
+		if unicode.ToLower(t[i]) != unicode.ToLower(p[j]) {
+			continue
+		}
+
+		switch {
+		case prev >= 0 && i == prev+1:
+			score += 5
+		case i == 0 || isSeparator(t[i-1]):
+			score += 3
+		default:
+			score++
+		}
+
+		if prev >= 0 {
+			score -= i - prev - 1
+		}
+
+		prev = i
+		j++
+	}
+
+	if j < len(p) {
+		return 0, false
+	}
+
+	return score, true
+}
+
+func isSeparator(r rune) bool {
+	return unicode.IsSpace(r) || unicode.IsPunct(r)
+}
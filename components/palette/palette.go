@@ -0,0 +1,520 @@
+package palette
+
+// Package palette provides a command palette: a popup listing the
+// commands registered in the application and filtering them with
+// fuzzy matching as the user types. Selecting an entry dispatches the
+// command associated with it.
+//
+// Commands can be registered from a keys.Bindings: selecting one of
+// them sends the key message of the binding, so anything reachable
+// through a key binding is automatically available in the palette.
+//
+// Like a dialog, the palette traps the input messages while it's
+// open; the application should check Captures before forwarding a
+// message to the other components.
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/remogatto/sugarfoam/keys"
+	"github.com/remogatto/sugarfoam/layout/overlay"
+)
+
+// DefaultWidth defines the default width of the palette.
+var DefaultWidth = 60
+
+// Command is an entry of the palette.
+type Command struct {
+	Name        string
+	Description string
+	Key         string
+
+	// Cmd is dispatched when the command is selected.
+	Cmd tea.Cmd
+}
+
+// Send returns a tea.Cmd sending msg, to be used as the Cmd of a
+// command dispatching a message.
+func Send(msg tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return msg
+	}
+}
+
+// FromBindings returns a command for each enabled binding of kb.
+// Selecting the command sends the key message of the first key of the
+// binding, so the bindings without keys are left out.
+func FromBindings(kb *keys.Bindings) []Command {
+	commands := make([]Command, 0)
+
+	for _, name := range kb.Names() {
+		b := kb.GetKey(name)
+		if !b.Enabled() || len(b.Keys()) == 0 {
+			continue
+		}
+
+		msg, ok := keyMsg(b.Keys()[0])
+		if !ok {
+			continue
+		}
+
+		c := Command{
+			Name:        b.Help().Desc,
+			Description: name,
+			Key:         b.Help().Key,
+			Cmd:         Send(msg),
+		}
+
+		if c.Name == "" {
+			c.Name = name
+			c.Description = ""
+		}
+		if c.Key == "" {
+			c.Key = b.Keys()[0]
+		}
+
+		commands = append(commands, c)
+	}
+
+	return commands
+}
+
+var keyTypes map[string]tea.KeyType
+
+// keyMsg returns the key message corresponding to a key as written
+// in a key binding, e.g. "ctrl+s" or "alt+right".
+func keyMsg(k string) (tea.KeyMsg, bool) {
+	if keyTypes == nil {
+		keyTypes = make(map[string]tea.KeyType)
+		for t := tea.KeyType(-128); t < 128; t++ {
+			if name := t.String(); name != "" {
+				keyTypes[name] = t
+			}
+		}
+	}
+
+	var msg tea.KeyMsg
+
+	if strings.HasPrefix(k, "alt+") && k != "alt+" {
+		msg.Alt = true
+		k = strings.TrimPrefix(k, "alt+")
+	}
+
+	if t, ok := keyTypes[k]; ok {
+		msg.Type = t
+		return msg, true
+	}
+
+	if utf8.RuneCountInString(k) == 1 {
+		msg.Type = tea.KeyRunes
+		msg.Runes = []rune(k)
+		return msg, true
+	}
+
+	return msg, false
+}
+
+// KeyMap defines the key bindings of the palette.
+type KeyMap struct {
+	Open   key.Binding
+	Close  key.Binding
+	Up     key.Binding
+	Down   key.Binding
+	Select key.Binding
+}
+
+// DefaultKeyMap returns the default key bindings of the palette.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Open: key.NewBinding(
+			key.WithKeys("ctrl+p"),
+			key.WithHelp("ctrl+p", "command palette"),
+		),
+		Close: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "close palette"),
+		),
+		Up: key.NewBinding(
+			key.WithKeys("up", "ctrl+k"),
+			key.WithHelp("↑", "prev command"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "ctrl+j"),
+			key.WithHelp("↓", "next command"),
+		),
+		Select: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "run command"),
+		),
+	}
+}
+
+// Styles defines the styles of the palette.
+type Styles struct {
+	Palette     lipgloss.Style
+	Input       lipgloss.Style
+	Item        lipgloss.Style
+	Selected    lipgloss.Style
+	Description lipgloss.Style
+	Key         lipgloss.Style
+}
+
+// DefaultStyles returns the default styles of the palette.
+func DefaultStyles() *Styles {
+	return &Styles{
+		Palette: lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("5")).
+			Padding(0, 1),
+		Input: lipgloss.NewStyle().
+			Border(lipgloss.NormalBorder(), false, false, true, false).
+			BorderForeground(lipgloss.Color("240")),
+		Item: lipgloss.NewStyle(),
+		Selected: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("229")).
+			Background(lipgloss.Color("57")),
+		Description: lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
+		Key:         lipgloss.NewStyle().Foreground(lipgloss.Color("5")),
+	}
+}
+
+// Option is a type for functions that modify a palette model.
+type Option func(*Model)
+
+// WithCommands adds commands to the palette.
+func WithCommands(commands ...Command) Option {
+	return func(m *Model) {
+		m.AddCommands(commands...)
+	}
+}
+
+// WithBindings adds a command for each binding of kb (see
+// FromBindings).
+func WithBindings(kb *keys.Bindings) Option {
+	return func(m *Model) {
+		m.AddCommands(FromBindings(kb)...)
+	}
+}
+
+// WithOverlay sets the overlay the palette is drawn on when opened.
+func WithOverlay(o *overlay.Model) Option {
+	return func(m *Model) {
+		m.overlay = o
+	}
+}
+
+// WithPosition sets the position of the palette on the overlay. The
+// palette is shown at the top center by default.
+func WithPosition(position overlay.Position) Option {
+	return func(m *Model) {
+		m.position = position
+	}
+}
+
+// WithMaxItems sets the maximum number of commands listed at once.
+func WithMaxItems(n int) Option {
+	return func(m *Model) {
+		m.maxItems = n
+	}
+}
+
+// WithWidth sets the width of the palette.
+func WithWidth(width int) Option {
+	return func(m *Model) {
+		m.width = width
+	}
+}
+
+// WithKeyMap sets the key bindings of the palette.
+func WithKeyMap(km KeyMap) Option {
+	return func(m *Model) {
+		m.KeyMap = km
+	}
+}
+
+// WithStyles sets the styles of the palette.
+func WithStyles(styles *Styles) Option {
+	return func(m *Model) {
+		m.styles = styles
+	}
+}
+
+// Model represents a command palette.
+type Model struct {
+	KeyMap KeyMap
+
+	input    textinput.Model
+	commands []Command
+	matches  []int
+	cursor   int
+	offset   int
+
+	width, height int
+	maxItems      int
+
+	opened   bool
+	overlay  *overlay.Model
+	position overlay.Position
+	styles   *Styles
+}
+
+// New creates a new palette with optional configurations.
+func New(opts ...Option) *Model {
+	input := textinput.New()
+	input.Placeholder = "Type a command..."
+	input.Prompt = "> "
+
+	m := &Model{
+		KeyMap:   DefaultKeyMap(),
+		input:    input,
+		width:    DefaultWidth,
+		maxItems: 10,
+		position: overlay.Offset(overlay.Align(lipgloss.Center, lipgloss.Top), 0, 2),
+		styles:   DefaultStyles(),
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// AddCommands adds commands to the palette.
+func (m *Model) AddCommands(commands ...Command) *Model {
+	m.commands = append(m.commands, commands...)
+	m.filter()
+
+	return m
+}
+
+// Commands returns the commands registered in the palette.
+func (m *Model) Commands() []Command {
+	return m.commands
+}
+
+// Matches returns the commands matching the current query, best
+// matches first.
+func (m *Model) Matches() []Command {
+	commands := make([]Command, 0)
+
+	for _, i := range m.matches {
+		commands = append(commands, m.commands[i])
+	}
+
+	return commands
+}
+
+// Open shows the palette on its overlay with an empty query.
+func (m *Model) Open() tea.Cmd {
+	if m.opened {
+		return nil
+	}
+
+	m.opened = true
+	m.input.Reset()
+	m.filter()
+
+	if m.overlay != nil {
+		m.overlay.Push(m, m.position)
+	}
+
+	return m.input.Focus()
+}
+
+// Close hides the palette.
+func (m *Model) Close() {
+	if !m.opened {
+		return
+	}
+
+	m.opened = false
+	m.input.Blur()
+
+	if m.overlay != nil {
+		m.overlay.Remove(m)
+	}
+}
+
+// Opened returns true if the palette is open.
+func (m *Model) Opened() bool {
+	return m.opened
+}
+
+// Captures returns true if msg must not reach the other components,
+// i.e. if the palette is open and msg is an input message, or if msg
+// is the key opening the palette.
+func (m *Model) Captures(msg tea.Msg) bool {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return m.opened || key.Matches(msg, m.KeyMap.Open)
+	case tea.MouseMsg:
+		return m.opened
+	}
+
+	return false
+}
+
+// Init initializes the palette.
+func (m *Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update opens the palette when its key binding is pressed and, while
+// it's open, handles the navigation keys and the query.
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if !m.opened {
+		if msg, ok := msg.(tea.KeyMsg); ok && key.Matches(msg, m.KeyMap.Open) {
+			return m, m.Open()
+		}
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.KeyMap.Close):
+			m.Close()
+			return m, nil
+
+		case key.Matches(msg, m.KeyMap.Up):
+			m.moveCursor(-1)
+			return m, nil
+
+		case key.Matches(msg, m.KeyMap.Down):
+			m.moveCursor(1)
+			return m, nil
+
+		case key.Matches(msg, m.KeyMap.Select):
+			if len(m.matches) == 0 {
+				return m, nil
+			}
+
+			cmd := m.commands[m.matches[m.cursor]].Cmd
+			m.Close()
+
+			return m, cmd
+		}
+	}
+
+	query := m.input.Value()
+
+	var cmd tea.Cmd
+
+	m.input, cmd = m.input.Update(msg)
+
+	if m.input.Value() != query {
+		m.filter()
+	}
+
+	return m, cmd
+}
+
+// View renders the palette.
+func (m *Model) View() string {
+	w := m.width - m.styles.Palette.GetHorizontalFrameSize()
+
+	m.input.Width = w - lipgloss.Width(m.input.Prompt) - 1
+
+	rows := []string{m.styles.Input.Width(w).Render(m.input.View())}
+
+	end := m.offset + m.maxItems
+	if end > len(m.matches) {
+		end = len(m.matches)
+	}
+
+	for i := m.offset; i < end; i++ {
+		rows = append(rows, m.renderCommand(m.commands[m.matches[i]], w, i == m.cursor))
+	}
+
+	if len(m.matches) == 0 {
+		rows = append(rows, m.styles.Description.Render("No matching commands"))
+	}
+
+	return m.styles.Palette.Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
+
+// GetWidth returns the current width of the palette.
+func (m *Model) GetWidth() int { return m.width }
+
+// GetHeight returns the current height of the palette.
+func (m *Model) GetHeight() int { return lipgloss.Height(m.View()) }
+
+func (m *Model) SetWidth(width int) { m.width = width }
+
+func (m *Model) SetHeight(height int) { m.height = height }
+
+func (m *Model) SetSize(width int, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m *Model) CanGrow() bool {
+	return false
+}
+
+func (m *Model) renderCommand(c Command, width int, selected bool) string {
+	style := m.styles.Item
+	if selected {
+		style = m.styles.Selected
+	}
+
+	left := c.Name
+	if c.Description != "" {
+		left += " " + m.styles.Description.Inherit(style).Render(c.Description)
+	}
+
+	right := m.styles.Key.Inherit(style).Render(c.Key)
+
+	gap := width - lipgloss.Width(left) - lipgloss.Width(right)
+	if gap < 1 {
+		gap = 1
+	}
+
+	return style.Render(left + strings.Repeat(" ", gap) + right)
+}
+
+// filter updates the matches for the current query, sorting them by
+// score. Commands with the same score keep their order.
+func (m *Model) filter() {
+	query := m.input.Value()
+	scores := make(map[int]int)
+
+	m.matches = make([]int, 0)
+
+	for i, c := range m.commands {
+		score, ok := Match(query, c.Name+" "+c.Description)
+		if !ok {
+			continue
+		}
+		scores[i] = score
+		m.matches = append(m.matches, i)
+	}
+
+	sort.SliceStable(m.matches, func(i, j int) bool {
+		return scores[m.matches[i]] > scores[m.matches[j]]
+	})
+
+	m.cursor = 0
+	m.offset = 0
+}
+
+func (m *Model) moveCursor(delta int) {
+	if len(m.matches) == 0 {
+		return
+	}
+
+	m.cursor = (m.cursor + delta + len(m.matches)) % len(m.matches)
+
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+m.maxItems {
+		m.offset = m.cursor - m.maxItems + 1
+	}
+}
@@ -0,0 +1,22 @@
+package palette
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/remogatto/sugarfoam/keys"
+)
+
+func TestFromBindingsWithoutKeys(t *testing.T) {
+	// A binding with an empty, non nil, list of keys is enabled.
+	kb := keys.New(
+		keys.WithBinding("save", key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("ctrl+s", "Save"))),
+		keys.WithBinding("none", key.NewBinding(key.WithKeys([]string{}...), key.WithHelp("", "Nothing"))),
+	)
+
+	commands := FromBindings(kb)
+
+	if len(commands) != 1 || commands[0].Name != "Save" {
+		t.Errorf("commands = %v, want only the save command", commands)
+	}
+}
@@ -0,0 +1,173 @@
+package tabgroup
+
+import (
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	foam "github.com/remogatto/sugarfoam"
+)
+
+const (
+	warningMarker = "▲"
+	errorMarker   = "✗"
+)
+
+// BadgeMsg sets the badge of a tab, e.g. an unread count. Tabs not
+// implementing SetBadge(string) ignore it.
+type BadgeMsg struct {
+	Tab   foam.Tabbable
+	Badge string
+}
+
+// StateMsg sets the state of a tab. Tabs not implementing
+// SetState(foam.TabState) ignore it.
+type StateMsg struct {
+	Tab   foam.Tabbable
+	State foam.TabState
+}
+
+// SetBadge returns a command setting the badge of tab, allowing
+// background work to update it.
+func SetBadge(tab foam.Tabbable, badge string) tea.Cmd {
+	return func() tea.Msg {
+		return BadgeMsg{Tab: tab, Badge: badge}
+	}
+}
+
+// SetState returns a command setting the state of tab. The title of
+// a busy tab shows a spinner.
+func SetState(tab foam.Tabbable, state foam.TabState) tea.Cmd {
+	return func() tea.Msg {
+		return StateMsg{Tab: tab, State: state}
+	}
+}
+
+// decorate handles the decoration messages. It returns false if msg
+// is not one of them. Messages for the tabs of nested tab groups are
+// forwarded to the tabs.
+func (tg *Model) decorate(msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case BadgeMsg:
+		if tg.IndexOf(msg.Tab) < 0 {
+			return tg.forward(msg), true
+		}
+		if t, ok := msg.Tab.(interface{ SetBadge(string) }); ok {
+			t.SetBadge(msg.Badge)
+		}
+		return nil, true
+
+	case StateMsg:
+		if tg.IndexOf(msg.Tab) < 0 {
+			return tg.forward(msg), true
+		}
+		if t, ok := msg.Tab.(interface{ SetState(foam.TabState) }); ok {
+			t.SetState(msg.State)
+		}
+		return tg.spin(), true
+
+	case spinner.TickMsg:
+		if msg.ID != tg.spinner.ID() {
+			return nil, false
+		}
+
+		if !tg.busy() {
+			tg.spinning = false
+			return nil, true
+		}
+
+		var cmd tea.Cmd
+		tg.spinner, cmd = tg.spinner.Update(msg)
+
+		return cmd, true
+	}
+
+	return nil, false
+}
+
+// forward sends msg to all the tabs, suspended ones included, so
+// that it reaches the nested tab group holding its tab.
+func (tg *Model) forward(msg tea.Msg) tea.Cmd {
+	cmds := make([]tea.Cmd, 0, len(tg.items))
+
+	for _, item := range tg.items {
+		_, cmd := item.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// spin starts the spinner if a tab is busy and it is not already
+// running, and the spinners of the nested tab groups.
+func (tg *Model) spin() tea.Cmd {
+	cmds := make([]tea.Cmd, 0)
+
+	for _, item := range tg.items {
+		for _, n := range nested(item) {
+			cmds = append(cmds, n.spin())
+		}
+	}
+
+	if !tg.spinning && tg.busy() {
+		tg.spinning = true
+		cmds = append(cmds, tg.spinner.Tick)
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// nested returns the outermost tab groups found down from f.
+func nested(f foam.Focusable) []*Model {
+	if tg, ok := f.(*Model); ok {
+		return []*Model{tg}
+	}
+
+	c, ok := f.(foam.Container)
+	if !ok {
+		return nil
+	}
+
+	groups := make([]*Model, 0)
+	for _, child := range c.Children() {
+		groups = append(groups, nested(child)...)
+	}
+
+	return groups
+}
+
+func (tg *Model) busy() bool {
+	for _, item := range tg.items {
+		if d, ok := item.(foam.Decorated); ok && d.State() == foam.TabBusy {
+			return true
+		}
+	}
+
+	return false
+}
+
+// decoratedTitle returns title with the icon, the state marker and
+// the badge of the tab at index i.
+func (tg *Model) decoratedTitle(i int, title string) string {
+	d, ok := tg.items[i].(foam.Decorated)
+	if !ok {
+		return title
+	}
+
+	switch d.State() {
+	case foam.TabWarning:
+		title = tg.styles.Warning.Render(warningMarker) + " " + title
+	case foam.TabError:
+		title = tg.styles.Error.Render(errorMarker) + " " + title
+	case foam.TabBusy:
+		title = tg.spinner.View() + " " + title
+	}
+
+	if icon := d.Icon(); icon != "" {
+		title = icon + " " + title
+	}
+
+	if badge := d.Badge(); badge != "" {
+		title += " " + tg.styles.Badge.Render(badge)
+	}
+
+	return title
+}
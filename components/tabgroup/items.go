@@ -0,0 +1,154 @@
+package tabgroup
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	foam "github.com/remogatto/sugarfoam"
+)
+
+// TabClosedMsg is emitted when a tab is removed from the tab group.
+// Index is the position the tab had.
+type TabClosedMsg struct {
+	Tab   foam.Tabbable
+	Index int
+}
+
+// TabMovedMsg is emitted when a tab is moved from a position to
+// another.
+type TabMovedMsg struct {
+	Tab      foam.Tabbable
+	From, To int
+}
+
+// WithClosable makes the tabs closable, rendering a close marker on
+// each title and enabling the TabClose key binding. A tab is closed
+// by clicking on its marker too.
+func WithClosable(closable bool) Option {
+	return func(m *Model) {
+		m.closable = closable
+	}
+}
+
+// WithCloseMarker sets the marker rendered on the titles of closable
+// tabs.
+func WithCloseMarker(marker string) Option {
+	return func(m *Model) {
+		m.closeMarker = marker
+	}
+}
+
+// InsertItem inserts a tab at index i, keeping the current tab. A tab
+// inserted after the tab group has been initialized is initialized on
+// its first activation, which is right away if the tab group was
+// empty.
+func (tg *Model) InsertItem(i int, item foam.Tabbable) tea.Cmd {
+	i = clamp(i, 0, len(tg.items))
+
+	tg.items = append(tg.items[:i], append([]foam.Tabbable{item}, tg.items[i:]...)...)
+
+	if len(tg.items) == 1 {
+		tg.currItemIndex = 0
+		if tg.started {
+			return tg.enter()
+		}
+		return nil
+	}
+
+	if i <= tg.currItemIndex {
+		tg.currItemIndex++
+	}
+
+	return nil
+}
+
+// RemoveItem removes the tab at index i. If it is the current tab,
+// the following one (or the previous one, if it was the last) becomes
+// current.
+func (tg *Model) RemoveItem(i int) tea.Cmd {
+	if i < 0 || i >= len(tg.items) {
+		return nil
+	}
+
+	item := tg.items[i]
+	current := i == tg.currItemIndex
+
+	var cmds []tea.Cmd
+
+	if current {
+		if tg.focused {
+			item.Blur()
+		}
+		cmds = append(cmds, tg.deactivate())
+	}
+
+	tg.items = append(tg.items[:i], tg.items[i+1:]...)
+	delete(tg.initialized, item)
+	delete(tg.queued, item)
+
+	if i < tg.currItemIndex || tg.currItemIndex >= len(tg.items) {
+		tg.currItemIndex--
+	}
+	if tg.currItemIndex < 0 {
+		tg.currItemIndex = 0
+	}
+
+	if current && len(tg.items) > 0 {
+		cmds = append(cmds, tg.enter())
+	}
+
+	cmds = append(cmds, func() tea.Msg {
+		return TabClosedMsg{Tab: item, Index: i}
+	})
+
+	return tea.Batch(cmds...)
+}
+
+// MoveItem moves the tab at index from to index to, shifting the tabs
+// in between. The current tab doesn't change.
+func (tg *Model) MoveItem(from, to int) tea.Cmd {
+	if from < 0 || from >= len(tg.items) {
+		return nil
+	}
+
+	to = clamp(to, 0, len(tg.items)-1)
+	if from == to {
+		return nil
+	}
+
+	item := tg.items[from]
+	current := tg.Current()
+
+	tg.items = append(tg.items[:from], tg.items[from+1:]...)
+	tg.items = append(tg.items[:to], append([]foam.Tabbable{item}, tg.items[to:]...)...)
+
+	for i, it := range tg.items {
+		if it == current {
+			tg.currItemIndex = i
+		}
+	}
+
+	return func() tea.Msg {
+		return TabMovedMsg{Tab: item, From: from, To: to}
+	}
+}
+
+// IndexOf returns the position of tab, or -1 if it doesn't belong to
+// the tab group.
+func (tg *Model) IndexOf(tab foam.Tabbable) int {
+	for i, item := range tg.items {
+		if item == tab {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func clamp(v, low, high int) int {
+	if v < low {
+		return low
+	}
+	if v > high {
+		return high
+	}
+	return v
+}
@@ -0,0 +1,177 @@
+package tabgroup
+
+import (
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	foam "github.com/remogatto/sugarfoam"
+)
+
+// maxQueued is the number of messages kept for a suspended tab.
+const maxQueued = 1024
+
+// TabChangedMsg is emitted when the current tab changes, either by
+// navigation or because the current tab has been closed.
+type TabChangedMsg struct {
+	Tab   foam.Tabbable
+	Index int
+}
+
+// ActivatedMsg is delivered to a tab when it becomes the current one,
+// including the first tab when the tab group is initialized. A
+// suspended tab resumes when it receives it.
+type ActivatedMsg struct {
+	Tab foam.Tabbable
+}
+
+// DeactivatedMsg is delivered to a tab when another tab becomes the
+// current one. A suspended tab receives no further messages until it
+// is activated again, when the messages it missed are delivered.
+type DeactivatedMsg struct {
+	Tab foam.Tabbable
+}
+
+// WithLazyInit defers the initialization of each tab to its first
+// activation, instead of initializing all the tabs in Init.
+func WithLazyInit(lazy bool) Option {
+	return func(m *Model) {
+		m.lazy = lazy
+	}
+}
+
+// WithSuspend suspends the tabs while hidden: only the current tab
+// receives the broadcast messages, e.g. ticks and async results. The
+// messages are queued for the hidden tabs, up to maxQueued per tab,
+// and delivered when they are activated again, so that the results
+// of the commands they started are not lost.
+func WithSuspend(suspend bool) Option {
+	return func(m *Model) {
+		m.suspend = suspend
+	}
+}
+
+// SetActive makes the tab at index i the current one.
+func (tg *Model) SetActive(i int) tea.Cmd {
+	if i < 0 || i >= len(tg.items) {
+		return nil
+	}
+
+	return tg.switchTab(i)
+}
+
+// SetActiveByTitle makes the first tab with the given title the
+// current one.
+func (tg *Model) SetActiveByTitle(title string) tea.Cmd {
+	for i, item := range tg.items {
+		if item.Title() == title {
+			return tg.switchTab(i)
+		}
+	}
+
+	return nil
+}
+
+// startIndex returns the index of the first tab flagged as active,
+// falling back to the current one.
+func (tg *Model) startIndex() int {
+	for i, item := range tg.items {
+		if item.Active() {
+			return i
+		}
+	}
+
+	return tg.currItemIndex
+}
+
+// gotoIndex returns the index of the tab a jump key binding such as
+// alt+3 refers to.
+func gotoIndex(msg tea.KeyMsg) int {
+	s := msg.String()
+
+	n, err := strconv.Atoi(s[len(s)-1:])
+	if err != nil {
+		return -1
+	}
+
+	return n - 1
+}
+
+// Initialized returns true if tab has been initialized.
+func (tg *Model) Initialized(tab foam.Tabbable) bool {
+	return tg.initialized[tab]
+}
+
+// Suspended returns true if tab doesn't receive messages because it
+// is hidden or not yet initialized.
+func (tg *Model) Suspended(tab foam.Tabbable) bool {
+	if len(tg.items) > 0 && tab == tg.Current() {
+		return false
+	}
+
+	return !tg.initialized[tab] || tg.suspend
+}
+
+func (tg *Model) initTab(tab foam.Tabbable) tea.Cmd {
+	if tg.initialized[tab] {
+		return nil
+	}
+
+	tg.initialized[tab] = true
+
+	return tab.Init()
+}
+
+// activate initializes the current tab if needed and delivers it an
+// ActivatedMsg.
+func (tg *Model) activate() tea.Cmd {
+	tab := tg.Current()
+
+	setActive(tab, true)
+
+	initCmd := tg.initTab(tab)
+	_, cmd := tab.Update(ActivatedMsg{tab})
+
+	cmds := []tea.Cmd{initCmd, cmd}
+
+	for _, msg := range tg.queued[tab] {
+		_, cmd := tab.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+	delete(tg.queued, tab)
+
+	return tea.Batch(cmds...)
+}
+
+// enqueue keeps msg for the suspended tab, dropping the oldest
+// message when the queue is full. Tabs not initialized yet have
+// nothing waiting for messages.
+func (tg *Model) enqueue(tab foam.Tabbable, msg tea.Msg) {
+	if !tg.initialized[tab] {
+		return
+	}
+
+	queue := tg.queued[tab]
+	if len(queue) >= maxQueued {
+		queue = queue[1:]
+	}
+
+	tg.queued[tab] = append(queue, msg)
+}
+
+func (tg *Model) deactivate() tea.Cmd {
+	tab := tg.Current()
+
+	setActive(tab, false)
+
+	_, cmd := tab.Update(DeactivatedMsg{tab})
+
+	return cmd
+}
+
+// setActive keeps the active flag of tab in sync, if the tab allows
+// it.
+func setActive(tab foam.Tabbable, active bool) {
+	if t, ok := tab.(interface{ SetActive(bool) }); ok {
+		t.SetActive(active)
+	}
+}
@@ -0,0 +1,534 @@
+package tabgroup
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/remogatto/sugarfoam/layout"
+	"github.com/remogatto/sugarfoam/layout/overlay"
+)
+
+const (
+	separator      = " • "
+	leftIndicator  = "‹ "
+	rightIndicator = " ›"
+	upIndicator    = "▴"
+	downIndicator  = "▾"
+	ellipsis       = "…"
+)
+
+// Position is the edge of the tab group the navbar is placed along.
+type Position int
+
+const (
+	Top Position = iota
+	Bottom
+	Left
+	Right
+)
+
+// WithPosition places the navbar along the given edge of the tab
+// group. On the left and right edges the navbar is rendered as a
+// vertical sidebar, using the Sidebar style.
+func WithPosition(position Position) Option {
+	return func(m *Model) {
+		m.position = position
+	}
+}
+
+// WithSidebarWidth sets the width of the vertical sidebar, including
+// its frame. By default the sidebar fits the widest title.
+func WithSidebarWidth(width int) Option {
+	return func(m *Model) {
+		m.sidebarWidth = width
+	}
+}
+
+// WithMaxTitleWidth truncates the titles longer than width cells,
+// ending them with an ellipsis.
+func WithMaxTitleWidth(width int) Option {
+	return func(m *Model) {
+		m.maxTitleWidth = width
+	}
+}
+
+// strip describes the range of titles visible in the navbar when
+// they don't fit it.
+type strip struct {
+	first, last int
+}
+
+func (s strip) visible(i int) bool {
+	return i >= s.first && i <= s.last
+}
+
+func (tg *Model) vertical() bool {
+	return tg.position == Left || tg.position == Right
+}
+
+// navStyle returns the style of the navbar, sized to span its edge
+// of the tab group.
+func (tg *Model) navStyle() lipgloss.Style {
+	if tg.vertical() {
+		return tg.styles.Sidebar.Copy().
+			Width(tg.sideWidth() - tg.styles.Sidebar.GetHorizontalBorderSize()).
+			Height(tg.GetHeight() - tg.styles.Sidebar.GetVerticalBorderSize())
+	}
+
+	return tg.styles.Navbar.Copy().Width(tg.GetWidth())
+}
+
+// sideWidth returns the width of the vertical sidebar.
+func (tg *Model) sideWidth() int {
+	if tg.sidebarWidth > 0 {
+		return tg.sidebarWidth
+	}
+
+	w := 0
+
+	for i := range tg.items {
+		if tw := lipgloss.Width(tg.titleStyle(i).Render(tg.titleText(i))); tw > w {
+			w = tw
+		}
+	}
+
+	return w + tg.styles.Sidebar.GetHorizontalFrameSize()
+}
+
+// indicators returns the rendered overflow indicators shown before
+// and after the visible titles.
+func (tg *Model) indicators() (string, string) {
+	if tg.vertical() {
+		return tg.styles.NavbarIndicator.Render(upIndicator), tg.styles.NavbarIndicator.Render(downIndicator)
+	}
+
+	return tg.styles.NavbarIndicator.Render(leftIndicator), tg.styles.NavbarIndicator.Render(rightIndicator)
+}
+
+// avail returns the room for the titles along the navbar.
+func (tg *Model) avail() int {
+	if tg.vertical() {
+		return tg.GetHeight() - tg.styles.Sidebar.GetVerticalFrameSize()
+	}
+
+	return tg.GetWidth() - tg.styles.Navbar.GetHorizontalFrameSize()
+}
+
+// strip returns the titles visible in the navbar. The navbar scrolls
+// from the first title shown last time only as much as needed to keep
+// the current tab visible.
+func (tg *Model) strip() strip {
+	n := len(tg.items)
+	if n == 0 {
+		return strip{0, -1}
+	}
+
+	titles := tg.titles()
+	before, after := tg.indicators()
+
+	avail := tg.avail()
+	if avail <= 0 {
+		return strip{0, n - 1}
+	}
+
+	size := func(first, last int) int {
+		var size int
+
+		if tg.vertical() {
+			size = lipgloss.Height(lipgloss.JoinVertical(lipgloss.Left, titles[first:last+1]...))
+			if first > 0 {
+				size += lipgloss.Height(before)
+			}
+			if last < n-1 {
+				size += lipgloss.Height(after)
+			}
+			return size
+		}
+
+		size = lipgloss.Width(strings.Join(titles[first:last+1], separator))
+		if first > 0 {
+			size += lipgloss.Width(before)
+		}
+		if last < n-1 {
+			size += lipgloss.Width(after)
+		}
+		return size
+	}
+
+	current := tg.currItemIndex
+
+	first := clamp(tg.offset, 0, current)
+	for first < current && size(first, current) > avail {
+		first++
+	}
+
+	last := current
+	for last < n-1 && size(first, last+1) <= avail {
+		last++
+	}
+	for first > 0 && size(first-1, last) <= avail {
+		first--
+	}
+
+	return strip{first, last}
+}
+
+// scroll remembers the first title shown by the navbar. It is called
+// whenever the current tab, the titles or the size of the tab group
+// may have changed.
+func (tg *Model) scroll() {
+	tg.offset = tg.strip().first
+}
+
+func (tg *Model) navbar() string {
+	s := tg.strip()
+	before, after := tg.indicators()
+
+	titles := tg.titles()[s.first : s.last+1]
+
+	if tg.vertical() {
+		lines := make([]string, 0)
+
+		if s.first > 0 {
+			lines = append(lines, before)
+		}
+		lines = append(lines, titles...)
+		if s.last < len(tg.items)-1 {
+			lines = append(lines, after)
+		}
+
+		return tg.navStyle().Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+	}
+
+	content := strings.Join(titles, separator)
+
+	if s.first > 0 {
+		content = before + content
+	}
+	if s.last < len(tg.items)-1 {
+		content += after
+	}
+
+	if avail := tg.avail(); avail > 0 {
+		content = ansi.Truncate(content, avail, ellipsis)
+	}
+
+	return tg.navStyle().Render(content)
+}
+
+func (tg *Model) titles() []string {
+	titles := make([]string, 0)
+
+	var width int
+	if tg.vertical() {
+		width = tg.sideWidth() - tg.styles.Sidebar.GetHorizontalFrameSize()
+	}
+
+	for i := range tg.Items() {
+		style := tg.titleStyle(i)
+		if width > 0 {
+			style = style.Copy().Width(width)
+		}
+		titles = append(titles, style.Render(tg.titleText(i)))
+	}
+
+	return titles
+}
+
+func (tg *Model) titleText(i int) string {
+	title := tg.items[i].Title()
+
+	if tg.maxTitleWidth > 0 {
+		title = ansi.Truncate(title, tg.maxTitleWidth, ellipsis)
+	}
+
+	title = tg.decoratedTitle(i, title)
+
+	if tg.closable {
+		return title + " " + tg.closeMarker
+	}
+
+	return title
+}
+
+func (tg *Model) titleStyle(i int) lipgloss.Style {
+	if tg.currItemIndex == i {
+		return tg.styles.NavbarTitleSelected
+	}
+
+	return tg.styles.NavbarTitleUnselected
+}
+
+// navRect returns the area of the navbar within the tab group.
+func (tg *Model) navRect() layout.Rect {
+	x, y := layout.FrameOffset(tg.Common.GetStyles().NoBorder)
+
+	navbar := tg.navbar()
+	w, h := lipgloss.Width(navbar), lipgloss.Height(navbar)
+
+	switch tg.position {
+	case Bottom:
+		return layout.Rect{X: x, Y: y + tg.GetHeight() - h, W: w, H: h}
+	case Right:
+		return layout.Rect{X: x + tg.GetWidth() - w, Y: y, W: w, H: h}
+	}
+
+	return layout.Rect{X: x, Y: y, W: w, H: h}
+}
+
+// contentRect returns the area left to the current tab within the
+// tab group.
+func (tg *Model) contentRect() layout.Rect {
+	x, y := layout.FrameOffset(tg.Common.GetStyles().NoBorder)
+	w, h := tg.GetWidth(), tg.GetHeight()
+
+	nav := tg.navRect()
+
+	switch tg.position {
+	case Bottom:
+		return layout.Rect{X: x, Y: y, W: w, H: h - nav.H}
+	case Left:
+		return layout.Rect{X: x + nav.W, Y: y, W: w - nav.W, H: h}
+	case Right:
+		return layout.Rect{X: x, Y: y, W: w - nav.W, H: h}
+	}
+
+	return layout.Rect{X: x, Y: y + nav.H, W: w, H: h - nav.H}
+}
+
+// navbarOrigin returns the position of the first title within the
+// tab group, before the overflow indicator.
+func (tg *Model) navbarOrigin() (int, int) {
+	nav := tg.navRect()
+	x, y := layout.FrameOffset(tg.navStyle())
+
+	return nav.X + x, nav.Y + y
+}
+
+// titleRects returns the area of each title within the tab group.
+// Hidden titles have an empty area.
+func (tg *Model) titleRects() []layout.Rect {
+	s := tg.strip()
+	x, y := tg.navbarOrigin()
+	before, _ := tg.indicators()
+
+	if s.first > 0 {
+		if tg.vertical() {
+			y += lipgloss.Height(before)
+		} else {
+			x += lipgloss.Width(before)
+		}
+	}
+
+	rects := make([]layout.Rect, len(tg.items))
+
+	for i, title := range tg.titles() {
+		if !s.visible(i) {
+			continue
+		}
+
+		w, h := lipgloss.Width(title), lipgloss.Height(title)
+		rects[i] = layout.Rect{X: x, Y: y, W: w, H: h}
+
+		if tg.vertical() {
+			y += h
+		} else {
+			x += w + lipgloss.Width(separator)
+		}
+	}
+
+	return rects
+}
+
+// closeRects returns the area of the close marker of each title
+// within the tab group. Hidden titles have an empty area.
+func (tg *Model) closeRects() []layout.Rect {
+	rects := make([]layout.Rect, 0)
+
+	w := lipgloss.Width(tg.closeMarker)
+
+	for i, r := range tg.titleRects() {
+		if r.W == 0 {
+			rects = append(rects, r)
+			continue
+		}
+
+		left, top := layout.FrameOffset(tg.titleStyle(i))
+		x := r.X + left + lipgloss.Width(tg.titleText(i)) - w
+
+		rects = append(rects, layout.Rect{X: x, Y: r.Y + top, W: w, H: 1})
+	}
+
+	return rects
+}
+
+// indicatorRects returns the area of the overflow indicators before
+// and after the visible titles within the tab group. Missing
+// indicators have an empty area.
+func (tg *Model) indicatorRects() (layout.Rect, layout.Rect) {
+	var first, last layout.Rect
+
+	s := tg.strip()
+	x, y := tg.navbarOrigin()
+	before, after := tg.indicators()
+
+	if s.first > 0 {
+		first = layout.Rect{X: x, Y: y, W: lipgloss.Width(before), H: lipgloss.Height(before)}
+	}
+
+	if s.last < len(tg.items)-1 {
+		r := tg.titleRects()[s.last]
+		if tg.vertical() {
+			last = layout.Rect{X: x, Y: r.Y + r.H, W: lipgloss.Width(after), H: lipgloss.Height(after)}
+		} else {
+			last = layout.Rect{X: r.X + r.W, Y: y, W: lipgloss.Width(after), H: lipgloss.Height(after)}
+		}
+	}
+
+	return first, last
+}
+
+// hidden returns the indexes of the tabs whose title doesn't fit the
+// navbar.
+func (tg *Model) hidden() []int {
+	s := tg.strip()
+
+	hidden := make([]int, 0)
+
+	for i := range tg.items {
+		if !s.visible(i) {
+			hidden = append(hidden, i)
+		}
+	}
+
+	return hidden
+}
+
+// OpenList opens the dropdown listing the hidden tabs, if any.
+func (tg *Model) OpenList() {
+	tg.listOpen = len(tg.hidden()) > 0
+	tg.listCursor = 0
+}
+
+// CloseList closes the dropdown listing the hidden tabs.
+func (tg *Model) CloseList() {
+	tg.listOpen = false
+}
+
+// ListOpened returns true if the dropdown listing the hidden tabs is
+// open.
+func (tg *Model) ListOpened() bool {
+	return tg.listOpen
+}
+
+func (tg *Model) list() string {
+	lines := make([]string, 0)
+
+	for k, i := range tg.hidden() {
+		style := tg.styles.ListItem
+		if k == tg.listCursor {
+			style = tg.styles.ListItemSelected
+		}
+
+		lines = append(lines, style.Render(tg.titleText(i)))
+	}
+
+	return tg.styles.List.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// listRect returns the area of the dropdown within the tab group,
+// next to the navbar on the side of the content.
+func (tg *Model) listRect() layout.Rect {
+	list := tg.list()
+	w, h := lipgloss.Width(list), lipgloss.Height(list)
+
+	nav := tg.navRect()
+	x, y := layout.FrameOffset(tg.Common.GetStyles().NoBorder)
+
+	r := layout.Rect{X: nav.X + nav.W - w, Y: nav.Y + nav.H, W: w, H: h}
+
+	switch tg.position {
+	case Bottom:
+		r.Y = nav.Y - h
+	case Left:
+		r.X, r.Y = nav.X+nav.W, nav.Y
+	case Right:
+		r.X, r.Y = nav.X-w, nav.Y
+	}
+
+	if r.X < x {
+		r.X = x
+	}
+	if r.Y < y {
+		r.Y = y
+	}
+
+	return r
+}
+
+// drawList draws the dropdown over view, the rendered tab group.
+func (tg *Model) drawList(view string) string {
+	if !tg.listOpen {
+		return view
+	}
+
+	r := tg.listRect()
+
+	return overlay.Composite(view, tg.list(), r.X, r.Y)
+}
+
+func (tg *Model) updateList(msg tea.KeyMsg) tea.Cmd {
+	hidden := tg.hidden()
+
+	switch {
+	case key.Matches(msg, tg.KeyMap.ListUp):
+		tg.listCursor = clamp(tg.listCursor-1, 0, len(hidden)-1)
+	case key.Matches(msg, tg.KeyMap.ListDown):
+		tg.listCursor = clamp(tg.listCursor+1, 0, len(hidden)-1)
+	case key.Matches(msg, tg.KeyMap.ListSelect):
+		return tg.selectListItem(tg.listCursor)
+	case key.Matches(msg, tg.KeyMap.ListClose, tg.KeyMap.TabList):
+		tg.CloseList()
+	}
+
+	return nil
+}
+
+// selectListItem switches to the k-th hidden tab and closes the
+// dropdown.
+func (tg *Model) selectListItem(k int) tea.Cmd {
+	hidden := tg.hidden()
+
+	tg.CloseList()
+
+	if k < 0 || k >= len(hidden) {
+		return nil
+	}
+
+	return tg.switchTab(hidden[k])
+}
+
+// handleListMouse selects the clicked entry of the dropdown. Clicks
+// outside of the dropdown close it. It returns false if msg has not
+// been handled.
+func (tg *Model) handleListMouse(msg tea.MouseMsg) (tea.Cmd, bool) {
+	r := tg.listRect()
+
+	if !r.Contains(msg.X, msg.Y) {
+		if msg.Action == tea.MouseActionPress {
+			tg.CloseList()
+		}
+		return nil, false
+	}
+
+	if msg.Action != tea.MouseActionPress || msg.Button != tea.MouseButtonLeft {
+		return nil, true
+	}
+
+	_, top := layout.FrameOffset(tg.styles.List)
+
+	return tg.selectListItem(msg.Y - r.Y - top), true
+}
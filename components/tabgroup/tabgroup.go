@@ -1,32 +1,59 @@
 package tabgroup
 
 import (
-	"fmt"
-	"strings"
-
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	foam "github.com/remogatto/sugarfoam"
+	"github.com/remogatto/sugarfoam/layout"
 )
 
 type Option func(*Model)
 
 func WithItems(items ...foam.Tabbable) Option {
 	return func(m *Model) {
-		m.items = items
+		m.items = append([]foam.Tabbable(nil), items...)
+	}
+}
+
+// WithRouter sets the router deciding which tabs receive each
+// message. Key messages go to the current tab and mouse messages to
+// the tab under the pointer by default (see foam.DefaultRouter).
+func WithRouter(router foam.Router) Option {
+	return func(m *Model) {
+		m.router = router
 	}
 }
 
 type KeyMap struct {
-	TabNext key.Binding
-	TabPrev key.Binding
+	TabNext  key.Binding
+	TabPrev  key.Binding
+	TabClose key.Binding
+	TabList  key.Binding
+	TabGoto  key.Binding
+
+	ListUp     key.Binding
+	ListDown   key.Binding
+	ListSelect key.Binding
+	ListClose  key.Binding
 }
 
 type Styles struct {
 	Navbar                lipgloss.Style
+	Sidebar               lipgloss.Style
 	NavbarTitleUnselected lipgloss.Style
 	NavbarTitleSelected   lipgloss.Style
+	NavbarIndicator       lipgloss.Style
+
+	List             lipgloss.Style
+	ListItem         lipgloss.Style
+	ListItemSelected lipgloss.Style
+
+	Badge   lipgloss.Style
+	Warning lipgloss.Style
+	Error   lipgloss.Style
+	Spinner lipgloss.Style
 }
 
 type Model struct {
@@ -39,6 +66,28 @@ type Model struct {
 
 	focused bool
 	styles  *Styles
+	router  foam.Router
+
+	started     bool
+	lazy        bool
+	suspend     bool
+	initialized map[foam.Tabbable]bool
+	queued      map[foam.Tabbable][]tea.Msg
+
+	closable    bool
+	closeMarker string
+
+	position      Position
+	sidebarWidth  int
+	offset        int
+	maxTitleWidth int
+	listOpen      bool
+	listCursor    int
+
+	spinner  spinner.Model
+	spinning bool
+
+	x, y int
 }
 
 func DefaultKeyMap() KeyMap {
@@ -50,15 +99,40 @@ func DefaultKeyMap() KeyMap {
 		TabPrev: key.NewBinding(
 			key.WithKeys("alt+left"),
 			key.WithHelp("alt+left", "Prev tab")),
+		TabClose: key.NewBinding(
+			key.WithKeys("ctrl+w"),
+			key.WithHelp("ctrl+w", "Close tab")),
+		TabGoto: key.NewBinding(
+			key.WithKeys("alt+1", "alt+2", "alt+3", "alt+4", "alt+5", "alt+6", "alt+7", "alt+8", "alt+9"),
+			key.WithHelp("alt+1…9", "Go to tab")),
+		TabList: key.NewBinding(
+			key.WithKeys("alt+l"),
+			key.WithHelp("alt+l", "List hidden tabs")),
+		ListUp: key.NewBinding(
+			key.WithKeys("up"),
+			key.WithHelp("↑", "Up")),
+		ListDown: key.NewBinding(
+			key.WithKeys("down"),
+			key.WithHelp("↓", "Down")),
+		ListSelect: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "Select tab")),
+		ListClose: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "Close list")),
 	}
 }
 
 func New(opts ...Option) *Model {
 	tg := &Model{
-		items: make([]foam.Tabbable, 0),
+		items:       make([]foam.Tabbable, 0),
+		initialized: make(map[foam.Tabbable]bool),
+		queued:      make(map[foam.Tabbable][]tea.Msg),
+		closeMarker: "×",
 	}
 
 	tg.KeyMap = DefaultKeyMap()
+	tg.router = foam.DefaultRouter
 
 	tg.Common.SetStyles(foam.DefaultStyles())
 	tg.styles = DefaultStyles()
@@ -67,6 +141,10 @@ func New(opts ...Option) *Model {
 		opt(tg)
 	}
 
+	tg.currItemIndex = tg.startIndex()
+
+	tg.spinner = spinner.New(spinner.WithSpinner(spinner.MiniDot), spinner.WithStyle(tg.styles.Spinner))
+
 	return tg
 }
 
@@ -74,15 +152,15 @@ func (tg *Model) Items() []foam.Tabbable {
 	return tg.items
 }
 
-func (tg *Model) AddItem(item foam.Tabbable) *Model {
-	tg.items = append(tg.items, item)
-
-	return tg
+// AddItem appends a tab. See InsertItem.
+func (tg *Model) AddItem(item foam.Tabbable) tea.Cmd {
+	return tg.InsertItem(len(tg.items), item)
 }
 
 func DefaultStyles() *Styles {
 	return &Styles{
-		Navbar: lipgloss.NewStyle().Padding(1, 1, 0, 1),
+		Navbar:  lipgloss.NewStyle().Padding(1, 1, 0, 1),
+		Sidebar: lipgloss.NewStyle().Padding(1, 1, 0, 1),
 		NavbarTitleUnselected: lipgloss.NewStyle().
 			Background(lipgloss.Color("#373B41")).
 			Foreground(lipgloss.Color("240")).
@@ -91,78 +169,233 @@ func DefaultStyles() *Styles {
 			Background(lipgloss.Color("5")).
 			Foreground(lipgloss.Color("#ffffff")).
 			Padding(0, 2, 0, 2),
+		NavbarIndicator: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240")),
+		List: lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("5")),
+		ListItem: lipgloss.NewStyle().
+			Padding(0, 1, 0, 1),
+		ListItemSelected: lipgloss.NewStyle().
+			Background(lipgloss.Color("5")).
+			Foreground(lipgloss.Color("#ffffff")).
+			Padding(0, 1, 0, 1),
+		Badge: lipgloss.NewStyle().
+			Background(lipgloss.Color("1")).
+			Foreground(lipgloss.Color("#ffffff")).
+			Padding(0, 1, 0, 1),
+		Warning: lipgloss.NewStyle().Foreground(lipgloss.Color("3")),
+		Error:   lipgloss.NewStyle().Foreground(lipgloss.Color("1")),
+		Spinner: lipgloss.NewStyle(),
 	}
 }
 
 func (tg *Model) Init() tea.Cmd {
 	var cmds []tea.Cmd
 
-	for _, item := range tg.items {
-		cmds = append(cmds, item.Init())
+	tg.started = true
+
+	if !tg.lazy {
+		for _, item := range tg.items {
+			cmds = append(cmds, tg.initTab(item))
+		}
+	}
+
+	if len(tg.items) > 0 {
+		tg.currItemIndex = tg.startIndex()
+
+		for _, item := range tg.items {
+			setActive(item, false)
+		}
+
+		cmds = append(cmds, tg.activate())
 	}
 
+	cmds = append(cmds, tg.spin())
+
 	return tea.Batch(cmds...)
 }
 
 func (tg *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	cmd := tg.update(msg)
+	tg.scroll()
+
+	return tg, cmd
+}
+
+func (tg *Model) update(msg tea.Msg) tea.Cmd {
 	var cmds []tea.Cmd
 
+	if msg, ok := msg.(tea.KeyMsg); ok && tg.listOpen {
+		return tg.updateList(msg)
+	}
+
+	if cmd, ok := tg.decorate(msg); ok {
+		return cmd
+	}
+
 	switch msg := msg.(type) {
 
 	case tea.KeyMsg:
 		switch {
+		case key.Matches(msg, tg.KeyMap.TabList):
+			tg.OpenList()
+			return nil
+
 		case key.Matches(msg, tg.KeyMap.TabNext):
-			tg.nextTab()
+			return tg.nextTab()
 
 		case key.Matches(msg, tg.KeyMap.TabPrev):
-			tg.prevTab()
+			return tg.prevTab()
+
+		case key.Matches(msg, tg.KeyMap.TabGoto):
+			return tg.SetActive(gotoIndex(msg))
+
+		case tg.closable && key.Matches(msg, tg.KeyMap.TabClose):
+			return tg.RemoveItem(tg.currItemIndex)
 		}
 	}
 
-	cmds = append(cmds, tg.updateTabItems(msg)...)
-
-	return tg, tea.Batch(cmds...)
+	cmds = append(cmds, tg.dispatch(msg)...)
 
+	return tea.Batch(cmds...)
 }
 
 func (tg *Model) Focus() tea.Cmd {
 	tg.focused = true
-	return nil
+
+	if len(tg.items) == 0 {
+		return nil
+	}
+
+	return tg.Current().Focus()
 }
 
 func (tg *Model) Blur() {
 	tg.focused = false
+
+	if len(tg.items) > 0 {
+		tg.Current().Blur()
+	}
+}
+
+// Children returns the current tab, the only one whose elements can
+// be focused.
+func (tg *Model) Children() []foam.Focusable {
+	if len(tg.items) == 0 {
+		return nil
+	}
+
+	return []foam.Focusable{tg.Current()}
 }
 
 func (tg *Model) SetSize(width int, height int) {
 	tg.Common.SetSize(width, height)
+	tg.scroll()
 
-	tg.styles.Navbar = tg.styles.Navbar.Width(tg.GetWidth())
+	content := tg.contentRect()
 
 	for _, item := range tg.items {
-		item.SetSize(tg.GetWidth(), tg.GetHeight())
+		item.SetSize(content.W, content.H)
 	}
+
+	layout.PlaceChildren(tg, tg.x, tg.y)
 }
 
 func (tg *Model) View() string {
-	var navbar string
+	navbar := tg.navbar()
+
+	if len(tg.items) == 0 {
+		return tg.Common.GetStyles().Focused.Render(navbar)
+	}
 
-	for i, item := range tg.Items() {
-		tabTitle := tg.styles.NavbarTitleUnselected.Render(item.Title())
-		if tg.currItemIndex == i {
-			tabTitle = tg.styles.NavbarTitleSelected.Render(item.Title())
+	var view string
+
+	content := tg.Current().View()
+
+	switch tg.position {
+	case Bottom:
+		view = lipgloss.JoinVertical(lipgloss.Left, content, navbar)
+	case Left:
+		view = lipgloss.JoinHorizontal(lipgloss.Top, navbar, content)
+	case Right:
+		view = lipgloss.JoinHorizontal(lipgloss.Top, content, navbar)
+	default:
+		view = lipgloss.JoinVertical(lipgloss.Left, navbar, content)
+	}
+
+	return tg.drawList(tg.Common.GetStyles().NoBorder.Render(view))
+
+}
+
+// Placements returns the current tab with the area it takes beside
+// the navbar.
+func (tg *Model) Placements() []layout.Placement {
+	if len(tg.items) == 0 {
+		return nil
+	}
+
+	return []layout.Placement{{Item: tg.Current(), Rect: tg.contentRect()}}
+}
+
+// SetOrigin moves the tab group to the absolute position x, y.
+func (tg *Model) SetOrigin(x, y int) {
+	tg.x, tg.y = x, y
+	layout.PlaceChildren(tg, x, y)
+}
+
+// Origin returns the absolute position of the tab group.
+func (tg *Model) Origin() (int, int) {
+	return tg.x, tg.y
+}
+
+// HitTest returns the innermost element drawn at the absolute
+// position x, y, or nil if there is none.
+func (tg *Model) HitTest(x, y int) layout.Placeable {
+	return layout.HitTest(tg, x-tg.x, y-tg.y)
+}
+
+// handleMouse selects the clicked tab, or dispatches msg to the
+// current tab if the pointer is over it.
+func (tg *Model) handleMouse(msg tea.MouseMsg) tea.Cmd {
+	if tg.listOpen {
+		if cmd, ok := tg.handleListMouse(msg); ok {
+			return cmd
 		}
-		navbar += fmt.Sprintf("%s • ", tabTitle)
 	}
 
-	navbar = tg.styles.Navbar.Render(strings.TrimRight(navbar, "• "))
+	if foam.IsClick(msg) {
+		left, right := tg.indicatorRects()
+		if left.Contains(msg.X, msg.Y) || right.Contains(msg.X, msg.Y) {
+			tg.OpenList()
+			return nil
+		}
+	}
 
-	if len(tg.items) > 0 {
-		return tg.Common.GetStyles().NoBorder.Render(lipgloss.JoinVertical(lipgloss.Top, navbar, tg.items[tg.currItemIndex].View()))
+	if foam.IsClick(msg) && tg.closable {
+		for i, r := range tg.closeRects() {
+			if r.Contains(msg.X, msg.Y) {
+				return tg.RemoveItem(i)
+			}
+		}
 	}
 
-	return tg.Common.GetStyles().Focused.Render(navbar)
+	if foam.IsClick(msg) {
+		for i, r := range tg.titleRects() {
+			if r.Contains(msg.X, msg.Y) {
+				return tg.switchTab(i)
+			}
+		}
+	}
 
+	for _, p := range tg.Placements() {
+		if p.Rect.Contains(msg.X, msg.Y) {
+			_, cmd := tg.Current().Update(foam.LocalMouse(msg, p.Rect))
+			return cmd
+		}
+	}
+
+	return nil
 }
 
 func (m *Model) Current() foam.Tabbable {
@@ -173,25 +406,94 @@ func (m *Model) CanGrow() bool {
 	return true
 }
 
-func (tg *Model) nextTab() {
-	tg.currItemIndex = (tg.currItemIndex + 1) % len(tg.items)
+func (tg *Model) nextTab() tea.Cmd {
+	if len(tg.items) == 0 {
+		return nil
+	}
 
-	tg.Current().SetSize(tg.GetWidth(), tg.GetHeight())
+	return tg.switchTab((tg.currItemIndex + 1) % len(tg.items))
 }
 
-func (tg *Model) prevTab() {
-	tg.currItemIndex = (tg.currItemIndex - 1) % len(tg.items)
+func (tg *Model) prevTab() tea.Cmd {
+	if len(tg.items) == 0 {
+		return nil
+	}
+
+	i := tg.currItemIndex - 1
+	if i < 0 {
+		i = len(tg.items) - 1
+	}
+
+	return tg.switchTab(i)
+}
+
+// switchTab makes the tab at index i the current one, moving the
+// focus to it if the tab group is focused.
+func (tg *Model) switchTab(i int) tea.Cmd {
+	if i == tg.currItemIndex {
+		return nil
+	}
 
-	if tg.currItemIndex < 0 {
-		tg.currItemIndex = len(tg.items) - 1
+	if tg.focused {
+		tg.Current().Blur()
 	}
-	tg.Current().SetSize(tg.GetWidth(), tg.GetHeight())
+
+	deactivateCmd := tg.deactivate()
+
+	tg.currItemIndex = i
+
+	return tea.Batch(deactivateCmd, tg.enter())
+}
+
+// enter sets up the tab that just became the current one: it is
+// resized, activated and focused if the tab group is focused.
+func (tg *Model) enter() tea.Cmd {
+	tg.scroll()
+
+	content := tg.contentRect()
+
+	tg.Current().SetSize(content.W, content.H)
+	layout.PlaceChildren(tg, tg.x, tg.y)
+
+	tab, index := tg.Current(), tg.currItemIndex
+
+	cmds := []tea.Cmd{tg.activate(), func() tea.Msg {
+		return TabChangedMsg{Tab: tab, Index: index}
+	}}
+
+	if tg.focused {
+		cmds = append(cmds, tg.Current().Focus())
+	}
+
+	return tea.Batch(cmds...)
+}
+
+func (tg *Model) dispatch(msg tea.Msg) []tea.Cmd {
+	switch tg.router(msg) {
+	case foam.Pointer:
+		if msg, ok := msg.(tea.MouseMsg); ok {
+			return []tea.Cmd{tg.handleMouse(msg)}
+		}
+		fallthrough
+	case foam.Focused:
+		if len(tg.items) == 0 {
+			return nil
+		}
+		_, cmd := tg.Current().Update(msg)
+		return []tea.Cmd{cmd}
+	}
+
+	return tg.updateTabItems(msg)
 }
 
 func (tg *Model) updateTabItems(msg tea.Msg) []tea.Cmd {
 	cmds := make([]tea.Cmd, 0)
 
 	for _, item := range tg.items {
+		if tg.Suspended(item) {
+			tg.enqueue(item, msg)
+			continue
+		}
 		_, cmd := item.Update(msg)
 		cmds = append(cmds, cmd)
 	}
@@ -0,0 +1,169 @@
+package tabgroup
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	btTable "github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	foam "github.com/remogatto/sugarfoam"
+	"github.com/remogatto/sugarfoam/components/tabgroup/tabitem"
+	"github.com/remogatto/sugarfoam/components/table"
+	"github.com/remogatto/sugarfoam/components/viewport"
+	"github.com/remogatto/sugarfoam/internal/foamtest"
+)
+
+func TestNestedDecoration(t *testing.T) {
+	innerTab := tabitem.New(viewport.New(), tabitem.WithTitle("Inner"))
+	inner := New(WithItems(innerTab))
+
+	outer := New(WithItems(
+		tabitem.New(viewport.New(), tabitem.WithTitle("First")),
+		tabitem.New(inner, tabitem.WithTitle("Nested")),
+	))
+	outer.Init()
+
+	_, cmd := outer.Update(StateMsg{Tab: innerTab, State: foam.TabBusy})
+
+	if innerTab.State() != foam.TabBusy {
+		t.Fatalf("state of the nested tab is %v, want busy", innerTab.State())
+	}
+	if !inner.spinning {
+		t.Error("the spinner of the nested tab group is not running")
+	}
+	if outer.spinning {
+		t.Error("the spinner of the outer tab group is running")
+	}
+	if cmd == nil {
+		t.Error("no command ticking the spinner of the nested tab group")
+	}
+
+	outer.Update(BadgeMsg{Tab: innerTab, Badge: "3"})
+
+	if innerTab.Badge() != "3" {
+		t.Errorf("badge of the nested tab is %q, want %q", innerTab.Badge(), "3")
+	}
+}
+
+func TestNestedSpin(t *testing.T) {
+	innerTab := tabitem.New(
+		viewport.New(),
+		tabitem.WithTitle("Inner"),
+		tabitem.WithState(foam.TabBusy),
+	)
+	inner := New(WithItems(innerTab))
+
+	outer := New(WithItems(tabitem.New(inner, tabitem.WithTitle("Nested"))))
+
+	if outer.spin() == nil || !inner.spinning {
+		t.Error("spin doesn't start the spinner of the nested tab group")
+	}
+}
+
+// source is a Fetcher data source of ten rows.
+type source struct{}
+
+func (source) Len() int            { return 10 }
+func (source) Row(int) btTable.Row { return nil }
+
+func (source) Fetch(r table.Range) ([]btTable.Row, error) {
+	rows := make([]btTable.Row, 0, r.End-r.Start)
+	for i := r.Start; i < r.End; i++ {
+		rows = append(rows, btTable.Row{strconv.Itoa(i)})
+	}
+	return rows, nil
+}
+
+// results runs cmd and returns the messages it produces, leaving out
+// the ticks.
+func results(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		var msgs []tea.Msg
+		for _, cmd := range msg {
+			msgs = append(msgs, results(cmd)...)
+		}
+		return msgs
+	case spinner.TickMsg:
+		return nil
+	default:
+		return []tea.Msg{msg}
+	}
+}
+
+func TestSuspendedFetch(t *testing.T) {
+	tbl := table.New(table.WithRelWidths(100), table.WithDataSource(source{}))
+	tbl.SetColumns([]btTable.Column{{Title: "#", Width: 4}})
+
+	tg := New(
+		WithSuspend(true),
+		WithItems(
+			tabitem.New(tbl, tabitem.WithTitle("Table")),
+			tabitem.New(viewport.New(), tabitem.WithTitle("Other")),
+		),
+	)
+	tg.SetSize(40, 12)
+
+	// The rows are being fetched when the tab is hidden.
+	fetches := tg.Init()
+	tg.SetActive(1)
+
+	for _, msg := range results(fetches) {
+		tg.Update(msg)
+	}
+
+	tg.SetActive(0)
+
+	if row := tbl.SelectedRow(); row == nil || row[0] != "0" {
+		t.Errorf("selected row is %v, want the first fetched row", row)
+	}
+}
+
+func TestAddItemAfterInit(t *testing.T) {
+	tg := New()
+	tg.Init()
+	tg.SetSize(40, 12)
+
+	field := foamtest.NewField("first")
+	tab := tabitem.New(field, tabitem.WithTitle("First"))
+
+	tg.AddItem(tab)
+
+	if !tg.Initialized(tab) || tg.Current() != tab {
+		t.Fatal("the first tab added after Init is not the initialized current tab")
+	}
+	if field.Width == 0 || field.Height == 0 {
+		t.Errorf("the first tab added after Init is %dx%d, want it sized", field.Width, field.Height)
+	}
+
+	activated := false
+	for _, msg := range field.Msgs {
+		if _, ok := msg.(ActivatedMsg); ok {
+			activated = true
+		}
+	}
+	if !activated {
+		t.Error("the first tab added after Init is not activated")
+	}
+}
+
+func TestRemoveItemKeepsSlice(t *testing.T) {
+	items := []foam.Tabbable{
+		tabitem.New(viewport.New(), tabitem.WithTitle("A")),
+		tabitem.New(viewport.New(), tabitem.WithTitle("B")),
+		tabitem.New(viewport.New(), tabitem.WithTitle("C")),
+	}
+	first := items[0]
+
+	tg := New(WithItems(items...))
+	tg.RemoveItem(0)
+
+	if items[0] != first {
+		t.Error("RemoveItem changed the slice passed to WithItems")
+	}
+}
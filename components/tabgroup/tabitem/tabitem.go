@@ -1,31 +1,90 @@
 package tabitem
 
+// Package tabitem provides the tabs of a tab group. A tab wraps any
+// foam.Groupable, e.g. a group or a form, and a plain foam.Focusable
+// is wrapped in a group of its own.
 import (
+	foam "github.com/remogatto/sugarfoam"
 	"github.com/remogatto/sugarfoam/components/group"
+	"github.com/remogatto/sugarfoam/layout"
 )
 
 type Option func(*Model)
 
 type Model struct {
-	*group.Model
+	foam.Groupable
 
 	title  string
 	active bool
+
+	icon  string
+	badge string
+	state foam.TabState
 }
 
 func (m *Model) Active() bool {
 	return m.active
 }
 
+func (m *Model) SetActive(active bool) {
+	m.active = active
+}
+
 func (m *Model) Title() string {
 	return m.title
 }
 
-func New(group *group.Model, opts ...Option) *Model {
+func (m *Model) Icon() string {
+	return m.icon
+}
+
+func (m *Model) Badge() string {
+	return m.badge
+}
+
+func (m *Model) State() foam.TabState {
+	return m.state
+}
+
+func (m *Model) SetIcon(icon string) {
+	m.icon = icon
+}
+
+func (m *Model) SetBadge(badge string) {
+	m.badge = badge
+}
+
+func (m *Model) SetState(state foam.TabState) {
+	m.state = state
+}
+
+func (m *Model) Content() foam.Groupable {
+	return m.Groupable
+}
+
+func (m *Model) Children() []foam.Focusable {
+	return []foam.Focusable{m.Groupable}
+}
+
+func (m *Model) SetCurrent(item foam.Focusable) {
+	if s, ok := m.Groupable.(interface{ SetCurrent(foam.Focusable) }); ok {
+		s.SetCurrent(item)
+	}
+}
+
+func (m *Model) Placements() []layout.Placement {
+	return []layout.Placement{
+		{Item: m.Groupable, Rect: layout.Rect{X: 0, Y: 0, W: m.GetWidth(), H: m.GetHeight()}},
+	}
+}
+
+// New creates a tab hosting content. A content that is not a
+// foam.Groupable is wrapped in a group.
+func New(content foam.Focusable, opts ...Option) *Model {
 	m := &Model{
-		Model:  group,
-		title:  "Tab Item",
-		active: false,
+		Groupable: wrap(content),
+		title:     "Tab Item",
+		active:    false,
 	}
 
 	for _, opt := range opts {
@@ -47,12 +106,52 @@ func WithTitle(title string) Option {
 	}
 }
 
+func WithIcon(icon string) Option {
+	return func(m *Model) {
+		m.icon = icon
+	}
+}
+
+func WithBadge(badge string) Option {
+	return func(m *Model) {
+		m.badge = badge
+	}
+}
+
+func WithState(state foam.TabState) Option {
+	return func(m *Model) {
+		m.state = state
+	}
+}
+
 func WithGroup(group *group.Model) Option {
 	return func(m *Model) {
-		m.Model = group
+		m.Groupable = group
 	}
 }
 
+func WithContent(content foam.Focusable) Option {
+	return func(m *Model) {
+		m.Groupable = wrap(content)
+	}
+}
+
+func wrap(content foam.Focusable) foam.Groupable {
+	if g, ok := content.(foam.Groupable); ok {
+		return g
+	}
+
+	return group.New(
+		group.WithItems(content),
+		group.WithLayout(
+			layout.New(
+				layout.WithStyles(&layout.Styles{}),
+				layout.WithItem(content),
+			),
+		),
+	)
+}
+
 func (m *Model) CanGrow() bool {
 	return true
 }
@@ -0,0 +1,288 @@
+package table
+
+import (
+	"sync/atomic"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const defaultPageSize = 100
+
+var lastID int64
+
+func nextID() int {
+	return int(atomic.AddInt64(&lastID, 1))
+}
+
+// DataSource provides the rows of a table. The table only asks for
+// the rows it shows, so the rows don't need to be in memory at once.
+type DataSource interface {
+	// Len returns the number of rows.
+	Len() int
+
+	// Row returns the row at index i, or nil if it is not available
+	// yet.
+	Row(i int) table.Row
+}
+
+// Range is the range of rows from Start included to End excluded.
+type Range struct {
+	Start, End int
+}
+
+// Fetcher is implemented by data sources loading their rows
+// asynchronously, e.g. from a paginated API. The table fetches the
+// pages of rows it shows, and the adjacent ones, running Fetch in a
+// command. The fetched rows are kept by the table, and placeholder
+// rows are shown until they arrive.
+type Fetcher interface {
+	Fetch(r Range) ([]table.Row, error)
+}
+
+// Sorter is implemented by Fetcher data sources sorting their rows
+// themselves, e.g. through the query of a paginated API. The table
+// can't sort the rows of a Fetcher not implementing Sorter, as it
+// only knows the fetched ones.
+type Sorter interface {
+	// Sort sorts the rows by column col, in descending order if desc
+	// is true. A negative col restores the original order.
+	Sort(col int, desc bool)
+}
+
+// Filterer is implemented by Fetcher data sources filtering their
+// rows themselves. The table can't filter the rows of a Fetcher not
+// implementing Filterer.
+type Filterer interface {
+	// Filter narrows the rows to those matching query. An empty
+	// query restores all the rows.
+	Filter(query string)
+}
+
+// SliceSource is a data source holding its rows in a slice.
+type SliceSource []table.Row
+
+func (s SliceSource) Len() int {
+	return len(s)
+}
+
+func (s SliceSource) Row(i int) table.Row {
+	return s[i]
+}
+
+// FetchErrorMsg is emitted when a Fetcher fails to fetch a range of
+// rows. The range is fetched again when it is shown again.
+type FetchErrorMsg struct {
+	Range Range
+	Err   error
+}
+
+type fetchedMsg struct {
+	id   int
+	page int
+	rows []table.Row
+	err  error
+}
+
+// WithDataSource sets the data source of the table.
+func WithDataSource(src DataSource) Option {
+	return func(m *Model) {
+		m.source = src
+	}
+}
+
+// WithPageSize sets the number of rows fetched at once from a
+// Fetcher.
+func WithPageSize(n int) Option {
+	return func(m *Model) {
+		if n > 0 {
+			m.pageSize = n
+		}
+	}
+}
+
+// WithPlaceholder sets the text shown in the cells of the rows that
+// are being fetched.
+func WithPlaceholder(placeholder string) Option {
+	return func(m *Model) {
+		m.placeholder = placeholder
+	}
+}
+
+// SetDataSource sets the data source of the table, dropping the rows
+// fetched from the previous one. The returned command fetches the
+// shown rows.
+func (t *Model) SetDataSource(src DataSource) tea.Cmd {
+	t.source = src
+	t.Reload()
+
+	return t.fetch()
+}
+
+// DataSource returns the data source of the table.
+func (t *Model) DataSource() DataSource {
+	return t.source
+}
+
+// Reload drops the rows fetched so far, so that the shown rows are
+// fetched again.
+func (t *Model) Reload() {
+	// A new ID discards the pages still being fetched.
+	t.id = nextID()
+
+	t.pages = make(map[int][]table.Row)
+	t.pending = make(map[int]bool)
+	t.wanted = make(map[int]bool)
+
+	t.relocateSelection()
+	t.apply()
+}
+
+// lazy reports whether the rows are fetched from the data source,
+// and thus can only be sorted and filtered by the source itself.
+func (t *Model) lazy() bool {
+	_, ok := t.source.(Fetcher)
+	return ok
+}
+
+// sourceRow returns the row at index i of the data source, or nil if
+// it is not available yet.
+func (t *Model) sourceRow(i int) table.Row {
+	if row := t.source.Row(i); row != nil {
+		return row
+	}
+
+	page, ok := t.pages[i/t.pageSize]
+	if !ok || i%t.pageSize >= len(page) {
+		return nil
+	}
+
+	return page[i%t.pageSize]
+}
+
+// placeholderRow returns the row shown in place of a row that is
+// being fetched.
+func (t *Model) placeholderRow() table.Row {
+	row := make(table.Row, len(t.Columns()))
+	for i := range row {
+		row[i] = t.placeholder
+	}
+
+	return row
+}
+
+// want marks the page holding the row at index i of the data source,
+// and the adjacent ones, to be fetched.
+func (t *Model) want(i int) {
+	if _, ok := t.source.(Fetcher); !ok {
+		return
+	}
+
+	n := t.source.Len()
+
+	for page := i/t.pageSize - 1; page <= i/t.pageSize+1; page++ {
+		if page < 0 || page*t.pageSize >= n {
+			continue
+		}
+		if _, ok := t.pages[page]; ok || t.pending[page] {
+			continue
+		}
+
+		// The page is available from the data source itself.
+		if t.source.Row(page*t.pageSize) != nil {
+			continue
+		}
+
+		t.wanted[page] = true
+	}
+}
+
+// updateFetched sets the cell at column col of the fetched row at
+// index i of the data source, if any, so that the row shows an edit
+// made to the data source before it is fetched again.
+func (t *Model) updateFetched(i, col int, value string) {
+	page, ok := t.pages[i/t.pageSize]
+	if !ok || i%t.pageSize >= len(page) {
+		return
+	}
+
+	row := make(table.Row, len(page[i%t.pageSize]))
+	copy(row, page[i%t.pageSize])
+	row[col] = value
+
+	page[i%t.pageSize] = row
+}
+
+// evict drops the fetched pages away from the shown rows, from start
+// to end, keeping the adjacent pages.
+func (t *Model) evict(start, end int) {
+	if !t.lazy() {
+		return
+	}
+
+	first, last := start/t.pageSize-1, (end-1)/t.pageSize+1
+
+	for page := range t.pages {
+		if page < first || page > last {
+			delete(t.pages, page)
+		}
+	}
+}
+
+// fetch returns the commands fetching the wanted pages.
+func (t *Model) fetch() tea.Cmd {
+	f, ok := t.source.(Fetcher)
+	if !ok || len(t.wanted) == 0 {
+		return nil
+	}
+
+	cmds := make([]tea.Cmd, 0, len(t.wanted))
+
+	for page := range t.wanted {
+		delete(t.wanted, page)
+		t.pending[page] = true
+
+		r := t.pageRange(page)
+		id, page := t.id, page
+
+		cmds = append(cmds, func() tea.Msg {
+			rows, err := f.Fetch(r)
+			return fetchedMsg{id: id, page: page, rows: rows, err: err}
+		})
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// fetched stores the rows of a fetched page.
+func (t *Model) fetched(msg fetchedMsg) tea.Cmd {
+	delete(t.pending, msg.page)
+
+	if msg.err != nil {
+		r := t.pageRange(msg.page)
+		return func() tea.Msg {
+			return FetchErrorMsg{Range: r, Err: msg.err}
+		}
+	}
+
+	t.pages[msg.page] = msg.rows
+
+	if t.view != nil {
+		// The new rows may change the sort order and the filter
+		// matches.
+		t.apply()
+	} else {
+		t.refresh()
+	}
+
+	return nil
+}
+
+func (t *Model) pageRange(page int) Range {
+	r := Range{Start: page * t.pageSize, End: (page + 1) * t.pageSize}
+	if n := t.source.Len(); r.End > n {
+		r.End = n
+	}
+
+	return r
+}
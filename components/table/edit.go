@@ -0,0 +1,268 @@
+package table
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/remogatto/sugarfoam/layout"
+	"github.com/remogatto/sugarfoam/layout/overlay"
+)
+
+// Validator checks the new value of an edited cell, returning an
+// error if it is not valid.
+type Validator func(value string) error
+
+// CellEditor is implemented by data sources whose cells can be
+// changed. Edits to tables backed by other data sources are only
+// reported through CellEditedMsg.
+type CellEditor interface {
+	SetCell(i, col int, value string) error
+}
+
+// SetCell sets the cell at column col of row i. The row is replaced
+// by a copy, leaving the original row unchanged.
+func (s SliceSource) SetCell(i, col int, value string) error {
+	row := make(table.Row, len(s[i]))
+	copy(row, s[i])
+	row[col] = value
+
+	s[i] = row
+
+	return nil
+}
+
+// CellEditedMsg is emitted when the edit of a cell is committed. Row
+// is the index of the row in the data source.
+type CellEditedMsg struct {
+	Row, Col int
+	Old, New string
+}
+
+// WithEditable enables the edit of the cells. The arrow keys move
+// the cursor across the cells of the selected row.
+func WithEditable(enabled bool) Option {
+	return func(m *Model) {
+		m.editable = enabled
+	}
+}
+
+// WithValidator sets the validator of the cells of column col.
+func WithValidator(col int, v Validator) Option {
+	return func(m *Model) {
+		m.validators[col] = v
+	}
+}
+
+// SetValidator sets the validator of the cells of column col.
+func (t *Model) SetValidator(col int, v Validator) {
+	t.validators[col] = v
+}
+
+// Editable reports whether the cells can be edited.
+func (t *Model) Editable() bool {
+	return t.editable
+}
+
+// Editing reports whether a cell is being edited.
+func (t *Model) Editing() bool {
+	return t.editing
+}
+
+// EditError returns the error of the last commit, nil if the value
+// was valid.
+func (t *Model) EditError() error {
+	return t.editErr
+}
+
+// CellCursor returns the column of the cell under the cursor.
+func (t *Model) CellCursor() int {
+	return t.col
+}
+
+// SetCellCursor moves the cursor to the cell at column col of the
+// selected row.
+func (t *Model) SetCellCursor(col int) {
+	t.col = clamp(col, 0, len(t.Columns())-1)
+}
+
+// Edit opens the editor on the cell under the cursor.
+func (t *Model) Edit() tea.Cmd {
+	row := t.SelectedRow()
+	if !t.editable || row == nil || t.col >= len(row) || t.col >= len(t.Columns()) {
+		return nil
+	}
+
+	t.editing = true
+	t.editErr = nil
+
+	t.editor.SetValue(row[t.col])
+	t.editor.CursorEnd()
+
+	// A column too narrow for the cursor still gets an editor.
+	t.editor.Width = t.Columns()[t.col].Width - 1
+	if t.editor.Width < 1 {
+		t.editor.Width = 1
+	}
+
+	return t.editor.Focus()
+}
+
+// CommitEdit validates the value of the editor and stores it in the
+// cell. The editor stays open if the value is not valid.
+func (t *Model) CommitEdit() tea.Cmd {
+	if !t.editing {
+		return nil
+	}
+
+	row := t.SelectedRow()
+	if row == nil {
+		t.CancelEdit()
+		return nil
+	}
+
+	i, col := t.index(t.cursor), t.col
+	old, value := row[col], t.editor.Value()
+
+	if v, ok := t.validators[col]; ok {
+		if t.editErr = v(value); t.editErr != nil {
+			return nil
+		}
+	}
+
+	oldKey, _ := t.keyOf(i)
+
+	if e, ok := t.source.(CellEditor); ok {
+		if t.editErr = e.SetCell(i, col, value); t.editErr != nil {
+			return nil
+		}
+		t.updateFetched(i, col, value)
+	}
+
+	t.CancelEdit()
+	t.rekey(oldKey, i)
+
+	// The edit may have changed the row key.
+	t.sel = t.selectionAt(t.cursor)
+
+	if t.view != nil {
+		// The new value may change the sort order and the filter
+		// matches.
+		t.apply()
+	} else {
+		t.refresh()
+	}
+
+	return func() tea.Msg {
+		return CellEditedMsg{Row: i, Col: col, Old: old, New: value}
+	}
+}
+
+// CancelEdit closes the editor, leaving the cell unchanged.
+func (t *Model) CancelEdit() {
+	t.editing = false
+	t.editor.Blur()
+}
+
+func (t *Model) updateEdit(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, t.Keys.EditCommit):
+		return t.CommitEdit()
+	case key.Matches(msg, t.Keys.EditCancel):
+		t.CancelEdit()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	t.editor, cmd = t.editor.Update(msg)
+
+	return cmd
+}
+
+// handleEditKey handles the key bindings moving the cell cursor and
+// opening the editor.
+func (t *Model) handleEditKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if !t.editable {
+		return nil, false
+	}
+
+	switch {
+	case key.Matches(msg, t.Keys.Edit):
+		return t.Edit(), true
+	case key.Matches(msg, t.Keys.CellLeft):
+		t.SetCellCursor(t.col - 1)
+	case key.Matches(msg, t.Keys.CellRight):
+		t.SetCellCursor(t.col + 1)
+	default:
+		return nil, false
+	}
+
+	return nil, true
+}
+
+// cellRect returns the area of the cell at column col of the shown
+// row i, relative to the table.
+func (t *Model) cellRect(i, col int) layout.Rect {
+	frameX, _ := layout.FrameOffset(t.GetStyles().Focused)
+
+	if t.multiSelect {
+		col++
+	}
+
+	x := frameX + t.styles.Cell.GetPaddingLeft()
+	cols := t.Model.Columns()
+
+	for _, c := range cols[:col] {
+		x += c.Width + t.styles.Cell.GetHorizontalFrameSize()
+	}
+
+	return layout.Rect{
+		X: x,
+		Y: t.headerY() + t.headerHeight() + i - t.start,
+		W: cols[col].Width,
+		H: 1,
+	}
+}
+
+// drawCell draws the editor, or the cell cursor, over view.
+func (t *Model) drawCell(view string) string {
+	row := t.SelectedRow()
+	if !t.editable || !t.Focused() || row == nil || t.col >= len(row) {
+		return view
+	}
+
+	r := t.cellRect(t.cursor, t.col)
+	style := lipgloss.NewStyle().Width(r.W).MaxWidth(r.W)
+
+	var cell string
+
+	switch {
+	case t.editing && t.editErr != nil:
+		cell = style.Foreground(lipgloss.Color("9")).Render(t.editor.View())
+	case t.editing:
+		cell = style.Render(t.editor.View())
+	default:
+		cell = style.Reverse(true).Render(row[t.col])
+	}
+
+	return overlay.Composite(view, cell, r.X, r.Y)
+}
+
+func newEditor() textinput.Model {
+	ti := textinput.New()
+	ti.Prompt = ""
+
+	return ti
+}
+
+func clamp(v, low, high int) int {
+	if v > high {
+		v = high
+	}
+	if v < low {
+		v = low
+	}
+
+	return v
+}
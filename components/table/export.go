@@ -0,0 +1,298 @@
+package table
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Format is the format of an export: CSV, JSON lines or Markdown.
+type Format int
+
+const (
+	CSV Format = iota
+	JSONLines
+	Markdown
+)
+
+func (f Format) String() string {
+	switch f {
+	case CSV:
+		return "CSV"
+	case JSONLines:
+		return "JSON lines"
+	case Markdown:
+		return "Markdown"
+	}
+
+	return fmt.Sprintf("Format(%d)", int(f))
+}
+
+// ExportedMsg is emitted when the export key binding has saved the
+// table to a file, or failed to.
+type ExportedMsg struct {
+	Path   string
+	Format Format
+	Rows   int
+	Err    error
+}
+
+// WithExport enables the export key binding, saving the table to the
+// file at path in the given format.
+func WithExport(path string, format Format) Option {
+	return func(m *Model) {
+		m.exportPath = path
+		m.exportFormat = format
+	}
+}
+
+// Export writes the columns and the shown rows of the table to w in
+// the given format. The rows follow the sort order and the filter of
+// the table. The rows of a Fetcher data source that are not available
+// yet are fetched first, so Export blocks until they arrive.
+func (t *Model) Export(w io.Writer, format Format) error {
+	rows, err := t.exportRows()
+	if err != nil {
+		return err
+	}
+
+	return export(w, format, t.columnTitles(), rows)
+}
+
+// ExportCSV writes the table to w as CSV, with the column titles in
+// the first record.
+func (t *Model) ExportCSV(w io.Writer) error {
+	return t.Export(w, CSV)
+}
+
+// ExportJSON writes the table to w as JSON lines, one object per row
+// with the column titles as keys. Repeated titles get a numeric
+// suffix, e.g. "Name_2", so that no field is lost.
+func (t *Model) ExportJSON(w io.Writer) error {
+	return t.Export(w, JSONLines)
+}
+
+// ExportMarkdown writes the table to w as a Markdown table. A table
+// without columns can't be written in Markdown, so it is an error.
+func (t *Model) ExportMarkdown(w io.Writer) error {
+	return t.Export(w, Markdown)
+}
+
+// export saves the table to the export file. The rows are taken
+// right away, while the missing rows are fetched and the file is
+// written by the returned command.
+func (t *Model) export() tea.Cmd {
+	path, format := t.exportPath, t.exportFormat
+	titles := t.columnTitles()
+
+	rows := make([]table.Row, 0, t.count())
+	rows = append(rows, t.VisibleRows()...)
+	f, _ := t.source.(Fetcher)
+	pageSize := t.pageSize
+
+	return func() tea.Msg {
+		rows, err := fillRows(rows, f, pageSize)
+
+		var b bytes.Buffer
+		if err == nil {
+			err = export(&b, format, titles, rows)
+		}
+		if err == nil {
+			err = os.WriteFile(path, b.Bytes(), 0o644)
+		}
+
+		return ExportedMsg{Path: path, Format: format, Rows: len(rows), Err: err}
+	}
+}
+
+// columnTitles returns the titles of the columns, without the sort
+// indicator.
+func (t *Model) columnTitles() []string {
+	cols := t.Columns()
+	if len(t.titles) == len(cols) {
+		return t.titles
+	}
+
+	titles := make([]string, len(cols))
+	for i, col := range cols {
+		titles[i] = col.Title
+	}
+
+	return titles
+}
+
+// exportRows returns the shown rows, fetching the missing ones.
+func (t *Model) exportRows() ([]table.Row, error) {
+	f, _ := t.source.(Fetcher)
+	return fillRows(t.VisibleRows(), f, t.pageSize)
+}
+
+// fillRows fetches from f, page by page, the rows of a lazy source
+// missing in rows, and returns the available rows. The rows of a
+// lazy source are shown in the order of the source, so the shown row
+// i is the row i of the source.
+func fillRows(rows []table.Row, f Fetcher, pageSize int) ([]table.Row, error) {
+	filled := make([]table.Row, 0, len(rows))
+
+	for i := 0; i < len(rows); i++ {
+		if rows[i] == nil && f != nil {
+			r := Range{Start: i / pageSize * pageSize, End: (i/pageSize + 1) * pageSize}
+			if r.End > len(rows) {
+				r.End = len(rows)
+			}
+
+			fetched, err := f.Fetch(r)
+			if err != nil {
+				return nil, fmt.Errorf("fetching rows %d to %d: %w", r.Start, r.End, err)
+			}
+			for j, row := range fetched {
+				if k := r.Start + j; k < r.End && rows[k] == nil {
+					rows[k] = row
+				}
+			}
+		}
+
+		if rows[i] != nil {
+			filled = append(filled, rows[i])
+		}
+	}
+
+	return filled, nil
+}
+
+// export writes titles and rows to w in the given format.
+func export(w io.Writer, format Format, titles []string, rows []table.Row) error {
+	switch format {
+	case CSV:
+		return writeCSV(w, titles, rows)
+	case JSONLines:
+		return writeJSON(w, titles, rows)
+	case Markdown:
+		return writeMarkdown(w, titles, rows)
+	}
+
+	return fmt.Errorf("unknown export format %v", format)
+}
+
+func writeCSV(w io.Writer, titles []string, rows []table.Row) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(titles); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+func writeJSON(w io.Writer, titles []string, rows []table.Row) error {
+	keys := make([][]byte, len(titles))
+	for i, title := range jsonKeys(titles) {
+		k, err := json.Marshal(title)
+		if err != nil {
+			return err
+		}
+		keys[i] = k
+	}
+
+	for _, row := range rows {
+		var b bytes.Buffer
+
+		// The fields are written one by one to keep the order of the
+		// columns.
+		b.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+
+			v, err := json.Marshal(cell(row, i))
+			if err != nil {
+				return err
+			}
+
+			b.Write(k)
+			b.WriteByte(':')
+			b.Write(v)
+		}
+		b.WriteString("}\n")
+
+		if _, err := w.Write(b.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeMarkdown(w io.Writer, titles []string, rows []table.Row) error {
+	if len(titles) == 0 {
+		return errors.New("no columns to export")
+	}
+
+	lines := make([]string, 0, len(rows)+2)
+	lines = append(lines, markdownRow(titles))
+
+	sep := make([]string, len(titles))
+	for i := range sep {
+		sep[i] = "---"
+	}
+	lines = append(lines, markdownRow(sep))
+
+	for _, row := range rows {
+		cells := make([]string, len(titles))
+		for i := range cells {
+			cells[i] = cell(row, i)
+		}
+		lines = append(lines, markdownRow(cells))
+	}
+
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+
+	return err
+}
+
+// jsonKeys returns the titles made unique by appending a numeric
+// suffix to the repeated ones.
+func jsonKeys(titles []string) []string {
+	seen := make(map[string]bool, len(titles))
+	keys := make([]string, len(titles))
+
+	for i, title := range titles {
+		key := title
+		for n := 2; seen[key]; n++ {
+			key = fmt.Sprintf("%s_%d", title, n)
+		}
+
+		seen[key] = true
+		keys[i] = key
+	}
+
+	return keys
+}
+
+func markdownRow(cells []string) string {
+	escaped := make([]string, len(cells))
+	for i, c := range cells {
+		c = strings.ReplaceAll(c, `\`, `\\`)
+		c = strings.ReplaceAll(c, "|", `\|`)
+		escaped[i] = strings.ReplaceAll(c, "\n", " ")
+	}
+
+	return "| " + strings.Join(escaped, " | ") + " |"
+}
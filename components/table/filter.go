@@ -0,0 +1,163 @@
+package table
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// FilterFunc reports whether row matches the filter query.
+type FilterFunc func(row table.Row, query string) bool
+
+// DefaultFilter matches the rows having a cell that contains the
+// query, ignoring case.
+func DefaultFilter(row table.Row, query string) bool {
+	query = strings.ToLower(query)
+
+	for _, c := range row {
+		if strings.Contains(strings.ToLower(c), query) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WithFilter sets the function matching the rows against the filter
+// query.
+func WithFilter(f FilterFunc) Option {
+	return func(m *Model) {
+		m.filterFn = f
+	}
+}
+
+// SetFilter narrows the rows to those matching query. An empty query
+// shows all the rows. The rows of a Fetcher data source are filtered
+// by the source if it implements Filterer, and are not filtered
+// otherwise.
+func (t *Model) SetFilter(query string) {
+	if !t.canFilter() {
+		return
+	}
+
+	visible := t.filterVisible()
+
+	t.filter.SetValue(query)
+
+	t.applyFilter()
+	t.resizeFilter(visible)
+}
+
+// Filter returns the filter query.
+func (t *Model) Filter() string {
+	return t.filter.Value()
+}
+
+// Filtering reports whether the filter bar is being edited.
+func (t *Model) Filtering() bool {
+	return t.filtering
+}
+
+// StartFilter shows the filter bar and moves the keyboard input to
+// it.
+func (t *Model) StartFilter() tea.Cmd {
+	if !t.canFilter() {
+		return nil
+	}
+
+	visible := t.filterVisible()
+
+	t.filtering = true
+	cmd := t.filter.Focus()
+
+	t.resizeFilter(visible)
+
+	return cmd
+}
+
+// StopFilter moves the keyboard input back to the rows, keeping the
+// filter query. The filter bar is hidden if the query is empty.
+func (t *Model) StopFilter() {
+	visible := t.filterVisible()
+
+	t.filtering = false
+	t.filter.Blur()
+
+	t.resizeFilter(visible)
+}
+
+// ClearFilter clears the filter query and hides the filter bar.
+func (t *Model) ClearFilter() {
+	t.StopFilter()
+	t.SetFilter("")
+}
+
+func (t *Model) updateFilter(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, t.Keys.FilterAccept):
+		t.StopFilter()
+		return nil
+	case key.Matches(msg, t.Keys.FilterClear):
+		t.ClearFilter()
+		return nil
+	}
+
+	query := t.filter.Value()
+
+	var cmd tea.Cmd
+	t.filter, cmd = t.filter.Update(msg)
+
+	if t.filter.Value() != query {
+		t.applyFilter()
+	}
+
+	return cmd
+}
+
+func (t *Model) canFilter() bool {
+	_, ok := t.source.(Filterer)
+	return ok || !t.lazy()
+}
+
+// applyFilter narrows the rows to those matching the filter query,
+// asking a Fetcher data source to filter them.
+func (t *Model) applyFilter() {
+	if f, ok := t.source.(Filterer); ok && t.lazy() {
+		f.Filter(t.filter.Value())
+		t.Reload()
+		return
+	}
+
+	t.apply()
+}
+
+func (t *Model) filterVisible() bool {
+	return t.filtering || t.filter.Value() != ""
+}
+
+func (t *Model) filterHeight() int {
+	if !t.filterVisible() {
+		return 0
+	}
+	return lipgloss.Height(t.filter.View())
+}
+
+// resizeFilter gives the rows the lines taken or released by the
+// filter bar when it is shown or hidden.
+func (t *Model) resizeFilter(wasVisible bool) {
+	if t.filterVisible() != wasVisible && t.Common.GetHeight() > 0 {
+		t.SetHeight(t.Common.GetHeight() + t.GetStyles().Focused.GetVerticalFrameSize())
+	}
+}
+
+func newFilterInput() textinput.Model {
+	ti := textinput.New()
+	ti.Prompt = "/ "
+	ti.Placeholder = "filter"
+
+	return ti
+}
@@ -0,0 +1,408 @@
+package table
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	checked   = "[x]"
+	unchecked = "[ ]"
+)
+
+// SelectionChangedMsg is emitted when the user changes the set of
+// selected rows of a multi-select table. The selected rows are
+// returned by the SelectedRows method of the table.
+type SelectionChangedMsg struct {
+	Table *Model
+}
+
+// WithMultiSelect enables the selection of multiple rows, shown in a
+// checkbox column before the other columns.
+func WithMultiSelect(enabled bool) Option {
+	return func(m *Model) {
+		m.multiSelect = enabled
+	}
+}
+
+// MultiSelect reports whether multiple rows can be selected.
+func (t *Model) MultiSelect() bool {
+	return t.multiSelect
+}
+
+// IsSelected reports whether the shown row i is selected.
+func (t *Model) IsSelected(i int) bool {
+	if i < 0 || i >= t.count() {
+		return false
+	}
+
+	k, ok := t.keyOf(t.index(i))
+	if !ok {
+		// The rows that are not available yet are only known to be
+		// selected when all the rows are.
+		return t.inverted
+	}
+
+	_, ok = t.selected[k]
+
+	return ok != t.inverted
+}
+
+// Select adds the shown row i to the selection.
+func (t *Model) Select(i int) {
+	t.setSelected(i, true)
+	t.refreshSelection()
+}
+
+// Deselect removes the shown row i from the selection.
+func (t *Model) Deselect(i int) {
+	t.setSelected(i, false)
+	t.refreshSelection()
+}
+
+// ToggleSelect selects the shown row i if it is not selected,
+// deselects it otherwise.
+func (t *Model) ToggleSelect(i int) {
+	t.setSelected(i, !t.IsSelected(i))
+	t.refreshSelection()
+}
+
+// SelectAll selects all the shown rows, or deselects them if they
+// are all selected already.
+func (t *Model) SelectAll() {
+	all := t.allSelected()
+
+	if t.narrowed() {
+		for i := 0; i < t.count(); i++ {
+			t.setSelected(i, !all)
+		}
+	} else {
+		t.selected = make(map[string]int)
+		t.inverted = !all
+	}
+
+	t.refreshSelection()
+}
+
+// ClearSelection deselects all the rows.
+func (t *Model) ClearSelection() {
+	t.selected = make(map[string]int)
+	t.inverted = false
+	t.anchor = -1
+
+	t.refreshSelection()
+}
+
+// SelectedRows returns the selected rows in the order of the data
+// source. The rows that are not available yet are left out.
+func (t *Model) SelectedRows() []table.Row {
+	if t.inverted {
+		return t.scanSelected()
+	}
+
+	indices := make([]int, 0, len(t.selected))
+	for _, i := range t.selected {
+		indices = append(indices, i)
+	}
+	sort.Ints(indices)
+
+	rows := make([]table.Row, 0, len(indices))
+	for _, i := range indices {
+		// The rows may have been moved by the data source since they
+		// were selected.
+		if i >= t.source.Len() {
+			return t.scanSelected()
+		}
+
+		row := t.sourceRow(i)
+		if row == nil {
+			continue
+		}
+		if k, _ := t.keyOf(i); !t.selectedAt(k, i) {
+			return t.scanSelected()
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// handleSelectKey handles the selection key bindings. Any other key
+// ends the range selection.
+func (t *Model) handleSelectKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if !t.multiSelect {
+		return nil, false
+	}
+
+	switch {
+	case key.Matches(msg, t.Keys.Select):
+		t.anchor = -1
+		t.ToggleSelect(t.cursor)
+	case key.Matches(msg, t.Keys.SelectUp):
+		t.extendSelection(t.cursor - 1)
+	case key.Matches(msg, t.Keys.SelectDown):
+		t.extendSelection(t.cursor + 1)
+	case key.Matches(msg, t.Keys.SelectAll):
+		t.anchor = -1
+		t.SelectAll()
+	default:
+		t.anchor = -1
+		return nil, false
+	}
+
+	return t.selectionChanged(), true
+}
+
+// handleSelectClick toggles the row clicked in the checkbox column
+// and extends the selection to the row shift-clicked. It reports
+// whether the click was handled.
+func (t *Model) handleSelectClick(msg tea.MouseMsg, row int) (tea.Cmd, bool) {
+	if !t.multiSelect {
+		return nil, false
+	}
+
+	switch {
+	case msg.Shift:
+		t.extendSelection(row)
+	case t.columnAt(msg.X) == 0:
+		t.anchor = -1
+		t.SetCursor(row)
+		t.ToggleSelect(row)
+	default:
+		t.anchor = -1
+		return nil, false
+	}
+
+	return t.selectionChanged(), true
+}
+
+// extendSelection moves the cursor to the shown row i and selects the
+// rows from the anchor, the row where the range selection started, to
+// the cursor.
+func (t *Model) extendSelection(i int) {
+	if t.anchor < 0 {
+		t.anchor = t.cursor
+		t.base = copySelection(t.selected)
+	}
+
+	t.SetCursor(i)
+
+	t.selected = copySelection(t.base)
+
+	from, to := t.anchor, t.cursor
+	if from > to {
+		from, to = to, from
+	}
+
+	for j := from; j <= to; j++ {
+		t.setSelected(j, true)
+	}
+
+	t.refreshSelection()
+}
+
+func (t *Model) setSelected(i int, selected bool) {
+	if i < 0 || i >= t.count() {
+		return
+	}
+
+	i = t.index(i)
+
+	k, ok := t.keyOf(i)
+	if !ok {
+		return
+	}
+
+	if selected != t.inverted {
+		t.selected[k] = i
+	} else {
+		delete(t.selected, k)
+	}
+}
+
+// rekey moves the selection of the row at index i of the data source,
+// known by old, to its current key.
+func (t *Model) rekey(old string, i int) {
+	k, ok := t.keyOf(i)
+	if !ok || k == old {
+		return
+	}
+
+	if j, ok := t.selected[old]; ok {
+		delete(t.selected, old)
+		t.selected[k] = j
+	}
+	if j, ok := t.base[old]; ok {
+		delete(t.base, old)
+		t.base[k] = j
+	}
+}
+
+// selectedAt reports whether the row with key k was selected at
+// index i of the data source.
+func (t *Model) selectedAt(k string, i int) bool {
+	j, ok := t.selected[k]
+	return ok && j == i
+}
+
+// relocateSelection updates the index of the selected rows moved by
+// the data source, dropping those that are gone. The rows of a
+// Fetcher data source are not available, so they are relocated when
+// SelectedRows finds them moved.
+func (t *Model) relocateSelection() {
+	if t.lazy() || len(t.selected) == 0 {
+		return
+	}
+
+	moved := false
+	for k, i := range t.selected {
+		if i >= t.source.Len() {
+			moved = true
+			break
+		}
+		if key, _ := t.keyOf(i); key != k {
+			moved = true
+			break
+		}
+	}
+
+	if !moved {
+		return
+	}
+
+	selected := make(map[string]int, len(t.selected))
+	for i := 0; i < t.source.Len(); i++ {
+		k, _ := t.keyOf(i)
+		if _, ok := t.selected[k]; ok {
+			selected[k] = i
+		}
+	}
+
+	t.selected = selected
+}
+
+// scanSelected returns the selected rows walking all the rows of the
+// data source.
+func (t *Model) scanSelected() []table.Row {
+	rows := make([]table.Row, 0)
+
+	for i := 0; i < t.source.Len(); i++ {
+		k, ok := t.keyOf(i)
+		if !ok {
+			continue
+		}
+		if _, ok := t.selected[k]; ok == t.inverted {
+			continue
+		}
+		if row := t.sourceRow(i); row != nil {
+			rows = append(rows, row)
+		}
+	}
+
+	return rows
+}
+
+func (t *Model) allSelected() bool {
+	n := t.count()
+	if n == 0 {
+		return false
+	}
+
+	if t.narrowed() {
+		for i := 0; i < n; i++ {
+			if !t.IsSelected(i) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if t.inverted {
+		return len(t.selected) == 0
+	}
+
+	return len(t.selected) == n
+}
+
+// narrowed reports whether the filter hides some of the rows of the
+// data source. A Fetcher data source filters the rows itself, so all
+// its rows are shown.
+func (t *Model) narrowed() bool {
+	return !t.lazy() && t.filter.Value() != ""
+}
+
+func (t *Model) selectionChanged() tea.Cmd {
+	msg := SelectionChangedMsg{Table: t}
+
+	return func() tea.Msg {
+		return msg
+	}
+}
+
+// refreshSelection redraws the checkboxes.
+func (t *Model) refreshSelection() {
+	if !t.multiSelect {
+		return
+	}
+
+	t.decorateHeader()
+	t.refresh()
+}
+
+// keyOf returns the key identifying the row at index i of the data
+// source in the selection. It reports false if the row has a row key
+// but it is not available yet.
+func (t *Model) keyOf(i int) (string, bool) {
+	if t.rowKey == nil {
+		return strconv.Itoa(i), true
+	}
+
+	row := t.sourceRow(i)
+	if row == nil {
+		return "", false
+	}
+
+	return t.rowKey(row), true
+}
+
+// checkColumn returns the checkbox column, checked when all the
+// shown rows are selected.
+func (t *Model) checkColumn() table.Column {
+	title := unchecked
+	if t.allSelected() {
+		title = checked
+	}
+
+	return table.Column{Title: title, Width: lipgloss.Width(unchecked)}
+}
+
+// checkCell returns the checkbox of the shown row i.
+func (t *Model) checkCell(i int) string {
+	if t.IsSelected(i) {
+		return checked
+	}
+	return unchecked
+}
+
+// checkWidth returns the width taken by the checkbox column.
+func (t *Model) checkWidth() int {
+	if !t.multiSelect {
+		return 0
+	}
+	return lipgloss.Width(unchecked) + t.styles.Cell.GetHorizontalFrameSize()
+}
+
+func copySelection(selected map[string]int) map[string]int {
+	c := make(map[string]int, len(selected))
+	for k, i := range selected {
+		c[k] = i
+	}
+
+	return c
+}
@@ -0,0 +1,202 @@
+package table
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+)
+
+const (
+	ascIndicator  = " ▲"
+	descIndicator = " ▼"
+)
+
+// Comparator compares the values of two cells, returning a negative
+// number when a sorts before b, a positive number when a sorts after
+// b and zero when they are equal.
+type Comparator func(a, b string) int
+
+// CompareStrings compares the cells as case-insensitive strings. It
+// is the comparator of the columns that don't have one.
+func CompareStrings(a, b string) int {
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// CompareNumbers compares the cells as numbers. Cells that are not
+// numbers sort after those that are.
+func CompareNumbers(a, b string) int {
+	x, errA := strconv.ParseFloat(strings.TrimSpace(a), 64)
+	y, errB := strconv.ParseFloat(strings.TrimSpace(b), 64)
+
+	switch {
+	case errA != nil && errB != nil:
+		return CompareStrings(a, b)
+	case errA != nil:
+		return 1
+	case errB != nil:
+		return -1
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+
+	return 0
+}
+
+// CompareDates returns a comparator comparing the cells as dates in
+// the given time layout. Cells that are not dates sort after those
+// that are.
+func CompareDates(layout string) Comparator {
+	return func(a, b string) int {
+		x, errA := time.Parse(layout, strings.TrimSpace(a))
+		y, errB := time.Parse(layout, strings.TrimSpace(b))
+
+		switch {
+		case errA != nil && errB != nil:
+			return CompareStrings(a, b)
+		case errA != nil:
+			return 1
+		case errB != nil:
+			return -1
+		case x.Before(y):
+			return -1
+		case x.After(y):
+			return 1
+		}
+
+		return 0
+	}
+}
+
+// WithComparator sets the comparator used to sort column col.
+func WithComparator(col int, cmp Comparator) Option {
+	return func(m *Model) {
+		m.SetComparator(col, cmp)
+	}
+}
+
+// SetComparator sets the comparator used to sort column col.
+func (t *Model) SetComparator(col int, cmp Comparator) {
+	t.comparators[col] = cmp
+
+	if col == t.sortCol {
+		t.apply()
+	}
+}
+
+// SortBy sorts the rows by column col, in descending order if desc
+// is true. A negative col restores the original order of the rows.
+// The rows of a Fetcher data source are sorted by the source if it
+// implements Sorter, and are not sorted otherwise.
+func (t *Model) SortBy(col int, desc bool) {
+	if col >= len(t.Columns()) {
+		return
+	}
+
+	if col < 0 {
+		col, desc = -1, false
+	}
+
+	if t.lazy() {
+		s, ok := t.source.(Sorter)
+		if !ok {
+			return
+		}
+		s.Sort(col, desc)
+	}
+
+	t.sortCol = col
+	t.sortDesc = desc
+
+	t.decorateHeader()
+
+	if t.lazy() {
+		t.Reload()
+		return
+	}
+
+	t.apply()
+}
+
+// SortColumn returns the column the rows are sorted by, -1 if they
+// are not sorted, and whether the order is descending.
+func (t *Model) SortColumn() (int, bool) {
+	return t.sortCol, t.sortDesc
+}
+
+// ToggleSort sorts the rows by column col in ascending order, or
+// reverses the order if they are already sorted by col.
+func (t *Model) ToggleSort(col int) {
+	if col == t.sortCol {
+		t.SortBy(col, !t.sortDesc)
+		return
+	}
+
+	t.SortBy(col, false)
+}
+
+// cycleSort sorts the rows by the column following the sort column,
+// going back to the original order after the last one.
+func (t *Model) cycleSort() {
+	col := t.sortCol + 1
+	if col >= len(t.Columns()) {
+		col = -1
+	}
+
+	t.SortBy(col, false)
+}
+
+func (t *Model) sortView(view []int) {
+	cmp, ok := t.comparators[t.sortCol]
+	if !ok {
+		cmp = CompareStrings
+	}
+
+	sort.SliceStable(view, func(i, j int) bool {
+		c := cmp(cell(t.sourceRow(view[i]), t.sortCol), cell(t.sourceRow(view[j]), t.sortCol))
+		if t.sortDesc {
+			return c > 0
+		}
+		return c < 0
+	})
+}
+
+// decorateHeader marks the title of the sort column with the sort
+// direction.
+func (t *Model) decorateHeader() {
+	cols := t.Columns()
+
+	if len(t.titles) != len(cols) {
+		// The columns were set on the underlying table.
+		t.titles = make([]string, len(cols))
+		for i, col := range cols {
+			title := strings.TrimSuffix(col.Title, ascIndicator)
+			t.titles[i] = strings.TrimSuffix(title, descIndicator)
+		}
+	}
+
+	for i := range cols {
+		cols[i].Title = t.titles[i]
+
+		if i == t.sortCol {
+			if t.sortDesc {
+				cols[i].Title += descIndicator
+			} else {
+				cols[i].Title += ascIndicator
+			}
+		}
+	}
+
+	t.setColumns(cols)
+}
+
+func cell(row table.Row, col int) string {
+	if col < 0 || col >= len(row) {
+		return ""
+	}
+	return row[col]
+}
@@ -1,19 +1,181 @@
 package table
 
 import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	foam "github.com/remogatto/sugarfoam"
+	"github.com/remogatto/sugarfoam/layout"
 )
 
 type Option func(*Model)
 
+// KeyMap defines the key bindings for sorting, filtering, selecting,
+// editing and exporting the rows. The bindings moving the cursor are those of the embedded
+// table KeyMap.
+type KeyMap struct {
+	Sort         key.Binding
+	SortReverse  key.Binding
+	Filter       key.Binding
+	FilterAccept key.Binding
+	FilterClear  key.Binding
+	Select       key.Binding
+	SelectUp     key.Binding
+	SelectDown   key.Binding
+	SelectAll    key.Binding
+	CellLeft     key.Binding
+	CellRight    key.Binding
+	Edit         key.Binding
+	EditCommit   key.Binding
+	EditCancel   key.Binding
+	Export       key.Binding
+}
+
+// DefaultKeyMap returns the default sort, filter, selection, edit and
+// export key bindings.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Sort: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "sort by next column"),
+		),
+		SortReverse: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "reverse sort"),
+		),
+		Filter: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "filter"),
+		),
+		FilterAccept: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "apply filter"),
+		),
+		FilterClear: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "clear filter"),
+		),
+		Select: key.NewBinding(
+			key.WithKeys(" "),
+			key.WithHelp("space", "select"),
+		),
+		SelectUp: key.NewBinding(
+			key.WithKeys("shift+up"),
+			key.WithHelp("shift+↑", "select up"),
+		),
+		SelectDown: key.NewBinding(
+			key.WithKeys("shift+down"),
+			key.WithHelp("shift+↓", "select down"),
+		),
+		SelectAll: key.NewBinding(
+			key.WithKeys("ctrl+a"),
+			key.WithHelp("ctrl+a", "select all"),
+		),
+		CellLeft: key.NewBinding(
+			key.WithKeys("left"),
+			key.WithHelp("←", "cell left"),
+		),
+		CellRight: key.NewBinding(
+			key.WithKeys("right"),
+			key.WithHelp("→", "cell right"),
+		),
+		Edit: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "edit cell"),
+		),
+		EditCommit: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "commit edit"),
+		),
+		EditCancel: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel edit"),
+		),
+		Export: key.NewBinding(
+			key.WithKeys("ctrl+s"),
+			key.WithHelp("ctrl+s", "export"),
+		),
+	}
+}
+
+// Model wraps the Bubble Tea table model. The wrapper holds the data
+// source of the rows and the cursor, and hands the underlying table
+// only the rows that fit its height, so that the position of each
+// visible row is known and only those rows are materialized. The
+// methods of the underlying table dealing with rows, cursor and
+// styles are wrapped accordingly; the rows and the cursor set
+// directly on the embedded Model are overwritten on the next refresh.
+//
+// The rows can be sorted by column and narrowed by a filter query.
+// The cursor then refers to the rows as shown, and it follows the
+// selected row, identified by its row key, across sort and filter
+// changes.
 type Model struct {
 	foam.Common
 	*table.Model
 
 	RelWidths []int
+	Keys      KeyMap
+
+	id     int
+	source DataSource
+	cursor int
+	start  int
+
+	pageSize    int
+	placeholder string
+	pages       map[int][]table.Row
+	pending     map[int]bool
+	wanted      map[int]bool
+
+	// view maps the shown rows to the rows, nil when they are not
+	// sorted nor filtered.
+	view   []int
+	rowKey func(table.Row) string
+	sel    selection
+
+	styles      table.Styles
+	titles      []string
+	sortCol     int
+	sortDesc    bool
+	comparators map[int]Comparator
+
+	filter    textinput.Model
+	filterFn  FilterFunc
+	filtering bool
+
+	// selected maps the keys of the selected rows to their index in
+	// the data source. Once all the rows are selected, inverted is
+	// set and selected holds the deselected rows instead. anchor is
+	// the shown row where the range selection started and base the
+	// selection before it, -1 and nil outside of a range selection.
+	multiSelect bool
+	selected    map[string]int
+	inverted    bool
+	anchor      int
+	base        map[string]int
+
+	// col is the column of the cell under the cursor.
+	editable   bool
+	col        int
+	editing    bool
+	editor     textinput.Model
+	editErr    error
+	validators map[int]Validator
+
+	exportPath   string
+	exportFormat Format
+}
+
+// selection identifies the selected row by its index and its row
+// key.
+type selection struct {
+	index int
+	key   string
 }
 
 func New(opts ...Option) *Model {
@@ -26,8 +188,25 @@ func New(opts ...Option) *Model {
 	}
 
 	ti := &Model{
-		Model:     &t,
-		RelWidths: relWidths,
+		Model:       &t,
+		RelWidths:   relWidths,
+		Keys:        DefaultKeyMap(),
+		id:          nextID(),
+		source:      SliceSource(nil),
+		pageSize:    defaultPageSize,
+		placeholder: "…",
+		pages:       make(map[int][]table.Row),
+		pending:     make(map[int]bool),
+		wanted:      make(map[int]bool),
+		sel:         selection{index: -1},
+		sortCol:     -1,
+		comparators: make(map[int]Comparator),
+		filter:      newFilterInput(),
+		filterFn:    DefaultFilter,
+		selected:    make(map[string]int),
+		anchor:      -1,
+		editor:      newEditor(),
+		validators:  make(map[int]Validator),
 	}
 
 	ti.Common.SetStyles(foam.DefaultStyles())
@@ -43,6 +222,7 @@ func New(opts ...Option) *Model {
 		Background(lipgloss.Color("57")).
 		Bold(false)
 	ti.Model.SetStyles(s)
+	ti.styles = s
 
 	for _, opt := range opts {
 		opt(ti)
@@ -64,6 +244,23 @@ func WithStyles(styles *foam.Styles) Option {
 	}
 }
 
+// WithKeyMap sets the sort and filter key bindings.
+func WithKeyMap(km KeyMap) Option {
+	return func(m *Model) {
+		m.Keys = km
+	}
+}
+
+// WithRowKey sets the function identifying a row, used to keep the
+// selected row under the cursor when the rows are sorted, filtered or
+// replaced. By default rows are identified by their index in the
+// data source.
+func WithRowKey(f func(table.Row) string) Option {
+	return func(m *Model) {
+		m.rowKey = f
+	}
+}
+
 func (t *Model) Focus() tea.Cmd {
 	t.Model.Focus()
 
@@ -72,6 +269,13 @@ func (t *Model) Focus() tea.Cmd {
 
 func (t *Model) Blur() {
 	t.Model.Blur()
+
+	if t.filtering {
+		t.StopFilter()
+	}
+	if t.editing {
+		t.CancelEdit()
+	}
 }
 
 func (t *Model) SetWidth(width int) {
@@ -83,22 +287,63 @@ func (t *Model) SetWidth(width int) {
 	t.Model.SetWidth(availableW)
 
 	cols := make([]table.Column, 0)
+	colsW := availableW - t.checkWidth()
 
-	for i, col := range t.Model.Columns() {
-		colW := availableW * t.RelWidths[i] / 100
+	for i, col := range t.Columns() {
+		colW := colsW * t.RelWidths[i] / 100
 		col.Width = colW - table.DefaultStyles().Cell.GetHorizontalFrameSize() - 1
 		cols = append(cols, col)
 	}
 
+	t.setColumns(cols)
+
+	t.filter.Width = width - lipgloss.Width(t.filter.Prompt) - 1
+}
+
+// SetColumns sets the columns of the table.
+func (t *Model) SetColumns(cols []table.Column) {
+	t.titles = make([]string, len(cols))
+	for i, col := range cols {
+		t.titles[i] = col.Title
+	}
+
+	t.setColumns(cols)
+	t.decorateHeader()
+}
+
+// Columns returns the columns of the table, without the checkbox
+// column of a multi-select table.
+func (t *Model) Columns() []table.Column {
+	cols := t.Model.Columns()
+	if t.multiSelect && len(cols) > 0 {
+		return append([]table.Column(nil), cols[1:]...)
+	}
+
+	return append([]table.Column(nil), cols...)
+}
+
+// setColumns sets the columns of the underlying table, preceded by
+// the checkbox column of a multi-select table.
+func (t *Model) setColumns(cols []table.Column) {
+	if t.multiSelect {
+		cols = append([]table.Column{t.checkColumn()}, cols...)
+	}
+
 	t.Model.SetColumns(cols)
 }
 
+// SetHeight sets the height of the table, including its frame and
+// the filter bar.
 func (t *Model) SetHeight(h int) {
+	h -= t.filterHeight() + t.GetStyles().Focused.GetVerticalFrameSize()
+
 	t.Model.SetHeight(h)
 
 	hh := lipgloss.Height(t.Model.View()) - h
 
 	t.Model.SetHeight(h - hh)
+
+	t.refresh()
 }
 
 func (t *Model) SetSize(w, h int) {
@@ -108,6 +353,31 @@ func (t *Model) SetSize(w, h int) {
 	t.SetHeight(h)
 }
 
+// SetStyles sets the styles of the table.
+func (t *Model) SetStyles(s table.Styles) {
+	t.styles = s
+	t.Model.SetStyles(s)
+
+	t.refresh()
+}
+
+// UpdateViewport renders the rows shown by the table again.
+func (t *Model) UpdateViewport() {
+	t.refresh()
+}
+
+// FromValues sets the rows of the table from value, with one row per
+// line and the cells separated by separator.
+func (t *Model) FromValues(value, separator string) {
+	rows := make([]table.Row, 0)
+
+	for _, line := range strings.Split(value, "\n") {
+		rows = append(rows, strings.Split(line, separator))
+	}
+
+	t.SetRows(rows)
+}
+
 func (m *Model) SetRelWidths(percentages ...int) {
 	m.RelWidths = make([]int, 0)
 	m.RelWidths = append(m.RelWidths, percentages...)
@@ -120,29 +390,449 @@ func (m *Model) GetHeight() int {
 }
 
 func (t *Model) Init() tea.Cmd {
-	return nil
+	return t.fetch()
 }
 
 func (t *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg.(type) {
+	cmd := t.update(msg)
+
+	// Scrolling may have brought rows to fetch into view.
+	return t, tea.Batch(cmd, t.fetch())
+}
+
+func (t *Model) update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case fetchedMsg:
+		if msg.id != t.id {
+			return nil
+		}
+		return t.fetched(msg)
 	case tea.KeyMsg:
 		if !t.Focused() {
-			return t, nil
+			return nil
+		}
+		if t.editing {
+			return t.updateEdit(msg)
+		}
+		if t.filtering {
+			return t.updateFilter(msg)
 		}
+		if cmd, ok := t.handleKey(msg); ok {
+			return cmd
+		}
+	case tea.MouseMsg:
+		return t.handleMouse(msg)
 	}
 
 	table, cmd := t.Model.Update(msg)
 
 	t.Model = &table
 
-	return t, cmd
+	if t.filtering {
+		var fcmd tea.Cmd
+		t.filter, fcmd = t.filter.Update(msg)
+		cmd = tea.Batch(cmd, fcmd)
+	}
+	if t.editing {
+		var ecmd tea.Cmd
+		t.editor, ecmd = t.editor.Update(msg)
+		cmd = tea.Batch(cmd, ecmd)
+	}
+
+	return cmd
+}
+
+// SetRows sets the rows of the table, replacing its data source. The
+// edits of the cells leave rows unchanged.
+func (t *Model) SetRows(rows []table.Row) {
+	t.source = append(SliceSource(nil), rows...)
+	t.Reload()
+}
+
+// Rows returns all the rows of the table, regardless of sorting and
+// filtering. The rows of the data source that are not available yet
+// are nil.
+func (t *Model) Rows() []table.Row {
+	if s, ok := t.source.(SliceSource); ok {
+		return s
+	}
+
+	rows := make([]table.Row, t.source.Len())
+	for i := range rows {
+		rows[i] = t.sourceRow(i)
+	}
+
+	return rows
+}
+
+// VisibleRows returns the rows as shown, i.e. sorted and filtered.
+// The rows that are not available yet are nil.
+func (t *Model) VisibleRows() []table.Row {
+	if t.view == nil {
+		return t.Rows()
+	}
+
+	rows := make([]table.Row, len(t.view))
+	for i := range t.view {
+		rows[i] = t.row(i)
+	}
+
+	return rows
+}
+
+// SelectedRow returns the row under the cursor, or nil if no row is
+// shown or it is not available yet.
+func (t *Model) SelectedRow() table.Row {
+	if t.cursor < 0 || t.cursor >= t.count() {
+		return nil
+	}
+	return t.row(t.cursor)
+}
+
+// Cursor returns the index of the row under the cursor among the
+// shown rows.
+func (t *Model) Cursor() int {
+	return t.cursor
+}
+
+// SetCursor moves the cursor to the shown row at index n.
+func (t *Model) SetCursor(n int) {
+	t.cursor = n
+	t.refresh()
+
+	t.sel = t.selectionAt(t.cursor)
+}
+
+// MoveUp moves the cursor up by n rows.
+func (t *Model) MoveUp(n int) {
+	t.SetCursor(t.cursor - n)
+}
+
+// MoveDown moves the cursor down by n rows.
+func (t *Model) MoveDown(n int) {
+	t.SetCursor(t.cursor + n)
+}
+
+// GotoTop moves the cursor to the first row.
+func (t *Model) GotoTop() {
+	t.SetCursor(0)
+}
+
+// GotoBottom moves the cursor to the last row.
+func (t *Model) GotoBottom() {
+	t.SetCursor(t.count() - 1)
+}
+
+// RowAt returns the index of the shown row rendered at line y of the
+// table, or -1 if there is no row there.
+func (t *Model) RowAt(y int) int {
+	i := y - t.headerY() - t.headerHeight()
+	if i < 0 || i >= len(t.Model.Rows()) {
+		return -1
+	}
+
+	return t.start + i
+}
+
+// ColumnAt returns the index of the column rendered at column x of
+// the table, or -1 if there is no column there.
+func (t *Model) ColumnAt(x int) int {
+	col := t.columnAt(x)
+	if t.multiSelect {
+		col--
+	}
+	if col < 0 {
+		return -1
+	}
+
+	return col
+}
+
+// columnAt is like ColumnAt but it counts the checkbox column of a
+// multi-select table.
+func (t *Model) columnAt(x int) int {
+	frameX, _ := layout.FrameOffset(t.GetStyles().Focused)
+
+	left := frameX
+	for i, col := range t.Model.Columns() {
+		right := left + col.Width + t.styles.Header.GetHorizontalFrameSize()
+		if x >= left && x < right {
+			return i
+		}
+		left = right
+	}
+
+	return -1
+}
+
+// headerY returns the line of the header, below the filter bar.
+func (t *Model) headerY() int {
+	_, frameY := layout.FrameOffset(t.GetStyles().Focused)
+	return frameY + t.filterHeight()
+}
+
+func (t *Model) headerHeight() int {
+	return lipgloss.Height(t.Model.View()) - t.Model.Height()
+}
+
+func (t *Model) handleKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	km := t.Model.KeyMap
+
+	if cmd, ok := t.handleSelectKey(msg); ok {
+		return cmd, true
+	}
+	if cmd, ok := t.handleEditKey(msg); ok {
+		return cmd, true
+	}
+
+	switch {
+	case key.Matches(msg, t.Keys.Export) && t.exportPath != "":
+		return t.export(), true
+	case key.Matches(msg, t.Keys.Filter):
+		return t.StartFilter(), true
+	case key.Matches(msg, t.Keys.Sort):
+		t.cycleSort()
+	case key.Matches(msg, t.Keys.SortReverse):
+		if t.sortCol >= 0 {
+			t.SortBy(t.sortCol, !t.sortDesc)
+		}
+	case key.Matches(msg, km.LineUp):
+		t.MoveUp(1)
+	case key.Matches(msg, km.LineDown):
+		t.MoveDown(1)
+	case key.Matches(msg, km.PageUp):
+		t.MoveUp(t.Model.Height())
+	case key.Matches(msg, km.PageDown):
+		t.MoveDown(t.Model.Height())
+	case key.Matches(msg, km.HalfPageUp):
+		t.MoveUp(t.Model.Height() / 2)
+	case key.Matches(msg, km.HalfPageDown):
+		t.MoveDown(t.Model.Height() / 2)
+	case key.Matches(msg, km.GotoTop):
+		t.GotoTop()
+	case key.Matches(msg, km.GotoBottom):
+		t.GotoBottom()
+	default:
+		return nil, false
+	}
+
+	return nil, true
+}
+
+// handleMouse scrolls the table with the wheel, selects the clicked
+// row, sorts by the clicked column header and starts filtering when
+// the filter bar is clicked. The coordinates of msg are relative to
+// the table.
+func (t *Model) handleMouse(msg tea.MouseMsg) tea.Cmd {
+	if msg.Action != tea.MouseActionPress {
+		return nil
+	}
+
+	if t.editing {
+		// Clicking away from the editor commits the edit.
+		return t.CommitEdit()
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		t.MoveUp(1)
+	case tea.MouseButtonWheelDown:
+		t.MoveDown(1)
+	case tea.MouseButtonLeft:
+		hy := t.headerY()
+
+		switch {
+		case msg.Y < hy && t.filterVisible():
+			return t.StartFilter()
+		case msg.Y >= hy && msg.Y < hy+t.headerHeight():
+			if t.multiSelect && t.columnAt(msg.X) == 0 {
+				t.SelectAll()
+				return t.selectionChanged()
+			}
+			if col := t.ColumnAt(msg.X); col >= 0 {
+				t.ToggleSort(col)
+			}
+		default:
+			row := t.RowAt(msg.Y)
+			if row < 0 {
+				break
+			}
+			if cmd, ok := t.handleSelectClick(msg, row); ok {
+				return cmd
+			}
+			t.SetCursor(row)
+
+			if col := t.ColumnAt(msg.X); t.editable && col >= 0 {
+				t.SetCellCursor(col)
+			}
+		}
+	}
+
+	return nil
+}
+
+// apply filters and sorts the rows, then moves the cursor back on
+// the selected row if it is still shown. The rows of a Fetcher data
+// source are left as the source provides them.
+func (t *Model) apply() {
+	t.view = nil
+	t.anchor = -1
+
+	query := t.filter.Value()
+
+	if !t.lazy() && (query != "" || t.sortCol >= 0) {
+		n := t.source.Len()
+
+		view := make([]int, 0, n)
+		for i := 0; i < n; i++ {
+			if query == "" {
+				view = append(view, i)
+			} else if row := t.sourceRow(i); row != nil && t.filterFn(row, query) {
+				view = append(view, i)
+			}
+		}
+
+		if t.sortCol >= 0 {
+			t.sortView(view)
+		}
+
+		t.view = view
+	}
+
+	if i := t.find(t.sel); i >= 0 {
+		t.cursor = i
+	}
+
+	t.refresh()
+
+	if t.sel.index < 0 {
+		t.sel = t.selectionAt(t.cursor)
+	}
+}
+
+// find returns the index of the shown row matching sel, or -1.
+func (t *Model) find(sel selection) int {
+	if sel.index < 0 {
+		return -1
+	}
+
+	for i := 0; i < t.count(); i++ {
+		if t.rowKey != nil {
+			if row := t.row(i); row != nil && t.rowKey(row) == sel.key {
+				return i
+			}
+		} else if t.index(i) == sel.index {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (t *Model) selectionAt(i int) selection {
+	if i < 0 || i >= t.count() {
+		return selection{index: -1}
+	}
+
+	sel := selection{index: t.index(i)}
+	if t.rowKey != nil {
+		row := t.row(i)
+		if row == nil {
+			return selection{index: -1}
+		}
+		sel.key = t.rowKey(row)
+	}
+
+	return sel
+}
+
+// count returns the number of shown rows.
+func (t *Model) count() int {
+	if t.view == nil {
+		return t.source.Len()
+	}
+	return len(t.view)
+}
+
+// index returns the index in rows of the shown row i.
+func (t *Model) index(i int) int {
+	if t.view == nil {
+		return i
+	}
+	return t.view[i]
+}
+
+// row returns the shown row i, or nil if it is not available yet.
+func (t *Model) row(i int) table.Row {
+	return t.sourceRow(t.index(i))
+}
+
+// refresh clamps the cursor, scrolls the window of visible rows so
+// that the cursor is inside it and hands it to the underlying table.
+func (t *Model) refresh() {
+	n := t.count()
+
+	h := t.Model.Height()
+	if h < 1 {
+		h = 1
+	}
+
+	if t.cursor >= n {
+		t.cursor = n - 1
+	}
+	if t.cursor < 0 {
+		t.cursor = 0
+	}
+
+	if t.cursor < t.start {
+		t.start = t.cursor
+	}
+	if t.cursor >= t.start+h {
+		t.start = t.cursor - h + 1
+	}
+	if t.start > n-h {
+		t.start = n - h
+	}
+	if t.start < 0 {
+		t.start = 0
+	}
+
+	end := t.start + h
+	if end > n {
+		end = n
+	}
+
+	rows := make([]table.Row, 0, end-t.start)
+	for i := t.start; i < end; i++ {
+		row := t.row(i)
+		if row == nil {
+			row = t.placeholderRow()
+		}
+		if t.multiSelect {
+			row = append(table.Row{t.checkCell(i)}, row...)
+		}
+		rows = append(rows, row)
+
+		t.want(t.index(i))
+	}
+
+	t.evict(t.start, end)
+
+	t.Model.SetRows(rows)
+	t.Model.SetCursor(t.cursor - t.start)
 }
 
 func (t *Model) View() string {
+	view := t.Model.View()
+
+	if t.filterVisible() {
+		view = lipgloss.JoinVertical(lipgloss.Left, t.filter.View(), view)
+	}
+
 	if t.Focused() {
-		return t.GetStyles().Focused.Render(t.Model.View())
+		return t.drawCell(t.GetStyles().Focused.Render(view))
 	}
-	return t.GetStyles().Blurred.Render(t.Model.View())
+	return t.GetStyles().Blurred.Render(view)
 }
 
 func (m *Model) CanGrow() bool {
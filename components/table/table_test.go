@@ -0,0 +1,174 @@
+package table
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func newTestTable(opts ...Option) *Model {
+	t := New(append([]Option{WithRelWidths(50, 50)}, opts...)...)
+	t.SetColumns([]table.Column{{Title: "ID", Width: 4}, {Title: "Name", Width: 4}})
+
+	return t
+}
+
+func testRows(n int) []table.Row {
+	rows := make([]table.Row, n)
+	for i := range rows {
+		rows[i] = table.Row{strconv.Itoa(i), "row " + strconv.Itoa(i)}
+	}
+
+	return rows
+}
+
+func TestSetSizeIncludesFrame(t *testing.T) {
+	tbl := newTestTable()
+	tbl.SetRows(testRows(50))
+	tbl.SetSize(40, 12)
+
+	view := tbl.View()
+	if w, h := lipgloss.Width(view), lipgloss.Height(view); w != 40 || h != 12 {
+		t.Errorf("view is %dx%d, want 40x12", w, h)
+	}
+
+	tbl.Focus()
+	tbl.StartFilter()
+
+	if h := lipgloss.Height(tbl.View()); h != 12 {
+		t.Errorf("view with the filter bar is %d lines high, want 12", h)
+	}
+}
+
+// fetcher is a Fetcher data source of n rows, whose cells can be
+// changed.
+type fetcher struct {
+	n     int
+	edits map[[2]int]string
+}
+
+func (f *fetcher) Len() int            { return f.n }
+func (f *fetcher) Row(i int) table.Row { return nil }
+
+func (f *fetcher) Fetch(r Range) ([]table.Row, error) {
+	rows := make([]table.Row, 0, r.End-r.Start)
+	for i := r.Start; i < r.End; i++ {
+		row := table.Row{strconv.Itoa(i), "row " + strconv.Itoa(i)}
+		for col := range row {
+			if v, ok := f.edits[[2]int{i, col}]; ok {
+				row[col] = v
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func (f *fetcher) SetCell(i, col int, value string) error {
+	if f.edits == nil {
+		f.edits = make(map[[2]int]string)
+	}
+	f.edits[[2]int{i, col}] = value
+	return nil
+}
+
+// run runs cmd, passing the fetched pages to the table.
+func run(t *Model, cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, cmd := range msg {
+			run(t, cmd)
+		}
+	case fetchedMsg:
+		_, cmd := t.Update(msg)
+		run(t, cmd)
+	}
+}
+
+func TestFetchedPagesBounded(t *testing.T) {
+	tbl := newTestTable(WithDataSource(&fetcher{n: 100000}), WithPageSize(10))
+	tbl.SetSize(40, 12)
+	run(tbl, tbl.Init())
+
+	for i := 0; i < 200; i++ {
+		tbl.MoveDown(50)
+		run(tbl, tbl.fetch())
+	}
+
+	// The shown rows span at most two pages, plus the adjacent ones.
+	if n := len(tbl.pages); n > 4 {
+		t.Errorf("%d pages kept after scrolling, want at most 4", n)
+	}
+	if row := tbl.SelectedRow(); row == nil || row[0] != strconv.Itoa(tbl.Cursor()) {
+		t.Errorf("selected row is %v, want row %d", row, tbl.Cursor())
+	}
+}
+
+func TestEditFetchedRow(t *testing.T) {
+	tbl := newTestTable(WithDataSource(&fetcher{n: 100}), WithPageSize(10), WithEditable(true))
+	tbl.SetSize(40, 12)
+	tbl.Focus()
+	run(tbl, tbl.Init())
+
+	tbl.SetCellCursor(1)
+	tbl.Edit()
+	tbl.editor.SetValue("edited")
+	tbl.CommitEdit()
+
+	if row := tbl.SelectedRow(); row == nil || row[1] != "edited" {
+		t.Errorf("selected row is %v after the edit, want the edited value", row)
+	}
+}
+
+func TestExportFetcher(t *testing.T) {
+	tbl := newTestTable(WithDataSource(&fetcher{n: 25}), WithPageSize(10))
+	tbl.SetSize(40, 8)
+
+	var b bytes.Buffer
+	if err := tbl.Export(&b, CSV); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 26 || lines[25] != "24,row 24" {
+		t.Errorf("export has %d lines ending with %q, want 26 ending with the last row", len(lines), lines[len(lines)-1])
+	}
+}
+
+func TestExportJSONDuplicateTitles(t *testing.T) {
+	tbl := New(WithRelWidths(50, 50))
+	tbl.SetColumns([]table.Column{{Title: "Name", Width: 4}, {Title: "Name", Width: 4}})
+	tbl.SetRows([]table.Row{{"a", "b"}})
+
+	var b bytes.Buffer
+	if err := tbl.ExportJSON(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := b.String(), `{"Name":"a","Name_2":"b"}`+"\n"; got != want {
+		t.Errorf("export is %q, want %q", got, want)
+	}
+}
+
+func TestEditNarrowColumn(t *testing.T) {
+	tbl := New(WithEditable(true))
+	tbl.SetColumns([]table.Column{{Title: "ID", Width: 0}})
+	tbl.SetRows([]table.Row{{"1"}})
+	tbl.Focus()
+
+	tbl.Edit()
+
+	if tbl.editor.Width < 1 {
+		t.Errorf("editor is %d cells wide, want at least 1", tbl.editor.Width)
+	}
+	tbl.View()
+}
@@ -56,6 +56,13 @@ func (m *Model) Init() tea.Cmd {
 
 // Update updates the text input model based on the received message.
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg.(type) {
+	case tea.KeyMsg:
+		if !m.Focused() {
+			return m, nil
+		}
+	}
+
 	t, cmd := m.Model.Update(msg)
 	m.Model = &t
 
@@ -63,6 +63,13 @@ func (ti *Model) Init() tea.Cmd {
 
 // Update updates the text input model based on the received message.
 func (ti *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg.(type) {
+	case tea.KeyMsg:
+		if !ti.Focused() {
+			return ti, nil
+		}
+	}
+
 	t, cmd := ti.Model.Update(msg)
 	ti.Model = &t
 
@@ -0,0 +1,49 @@
+package toast
+
+import (
+	"strings"
+
+	"github.com/remogatto/sugarfoam/components/viewport"
+)
+
+// History is a scrollable view of the past notifications of a
+// manager, the newest at the bottom. Its content is refreshed by the
+// manager when a notification arrives.
+type History struct {
+	*viewport.Model
+
+	manager *Model
+}
+
+// NewHistory creates a new history view for the given manager.
+func NewHistory(manager *Model, opts ...viewport.Option) *History {
+	h := &History{
+		Model:   viewport.New(opts...),
+		manager: manager,
+	}
+
+	manager.histories = append(manager.histories, h)
+	h.refresh()
+
+	return h
+}
+
+// SetSize sets the dimensions of the history, scrolling it to the
+// newest notification.
+func (h *History) SetSize(width int, height int) {
+	h.Model.SetSize(width, height)
+	h.GotoBottom()
+}
+
+// refresh renders the notifications of the manager and scrolls to the
+// newest one.
+func (h *History) refresh() {
+	lines := make([]string, 0)
+
+	for _, n := range h.manager.History() {
+		lines = append(lines, format(n))
+	}
+
+	h.SetContent(strings.Join(lines, "\n"))
+	h.GotoBottom()
+}
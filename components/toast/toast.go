@@ -0,0 +1,371 @@
+package toast
+
+// Package toast provides a notification manager showing transient
+// messages (toasts) stacked in a corner of the screen. Any component
+// can show a toast by returning one of the commands Info, Success,
+// Warning and Error. Toasts are dismissed automatically after a
+// timeout, and all the notifications are kept in a history that can
+// be browsed through the History component.
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/remogatto/sugarfoam/layout/overlay"
+)
+
+// DefaultTimeout is the time after which a toast is dismissed, unless
+// specified otherwise.
+var DefaultTimeout = 3 * time.Second
+
+// Level is the severity of a notification.
+type Level int
+
+const (
+	InfoLevel Level = iota
+	SuccessLevel
+	WarningLevel
+	ErrorLevel
+)
+
+func (l Level) String() string {
+	switch l {
+	case SuccessLevel:
+		return "success"
+	case WarningLevel:
+		return "warning"
+	case ErrorLevel:
+		return "error"
+	}
+	return "info"
+}
+
+// Notification represents a notification handled by the manager.
+type Notification struct {
+	ID      int
+	Level   Level
+	Text    string
+	Time    time.Time
+	Timeout time.Duration
+}
+
+// Msg asks the manager to show a notification. A zero Timeout means
+// DefaultTimeout, a negative one means the toast is never dismissed
+// automatically.
+type Msg struct {
+	Level   Level
+	Text    string
+	Timeout time.Duration
+}
+
+type dismissMsg struct {
+	id int
+}
+
+// Notify returns a command showing a toast with the given level,
+// dismissed after DefaultTimeout.
+func Notify(level Level, text string) tea.Cmd {
+	return NotifyFor(level, text, 0)
+}
+
+// NotifyFor returns a command showing a toast with the given level,
+// dismissed after timeout. See Msg for the meaning of the zero and
+// negative timeouts.
+func NotifyFor(level Level, text string, timeout time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		return Msg{Level: level, Text: text, Timeout: timeout}
+	}
+}
+
+// Info returns a command showing an informative toast.
+func Info(text string) tea.Cmd { return Notify(InfoLevel, text) }
+
+// Success returns a command showing a success toast.
+func Success(text string) tea.Cmd { return Notify(SuccessLevel, text) }
+
+// Warning returns a command showing a warning toast.
+func Warning(text string) tea.Cmd { return Notify(WarningLevel, text) }
+
+// Error returns a command showing an error toast.
+func Error(text string) tea.Cmd { return Notify(ErrorLevel, text) }
+
+// Styles defines the styles of the toasts. Toast is the base style,
+// the level styles are applied on top of it.
+type Styles struct {
+	Toast   lipgloss.Style
+	Info    lipgloss.Style
+	Success lipgloss.Style
+	Warning lipgloss.Style
+	Error   lipgloss.Style
+}
+
+// DefaultStyles returns the default styles of the toasts.
+func DefaultStyles() *Styles {
+	return &Styles{
+		Toast:   lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1).Width(40),
+		Info:    lipgloss.NewStyle().BorderForeground(lipgloss.Color("5")),
+		Success: lipgloss.NewStyle().BorderForeground(lipgloss.Color("2")),
+		Warning: lipgloss.NewStyle().BorderForeground(lipgloss.Color("3")),
+		Error:   lipgloss.NewStyle().BorderForeground(lipgloss.Color("1")),
+	}
+}
+
+// Option is a type for functions that modify a toast manager.
+type Option func(*Model)
+
+// WithOverlay sets the overlay the toasts are drawn on.
+func WithOverlay(o *overlay.Model) Option {
+	return func(m *Model) {
+		m.overlay = o
+	}
+}
+
+// WithPosition sets the position of the stack of toasts on the
+// overlay. Toasts are shown in the bottom-right corner by default.
+func WithPosition(position overlay.Position) Option {
+	return func(m *Model) {
+		m.position = position
+	}
+}
+
+// WithMaxVisible sets the maximum number of toasts shown at the same
+// time. Further toasts are queued until a slot is free.
+func WithMaxVisible(n int) Option {
+	return func(m *Model) {
+		m.maxVisible = n
+	}
+}
+
+// WithHistorySize sets the number of notifications kept in the
+// history.
+func WithHistorySize(n int) Option {
+	return func(m *Model) {
+		m.historySize = n
+	}
+}
+
+// WithStyles sets the styles of the toasts.
+func WithStyles(styles *Styles) Option {
+	return func(m *Model) {
+		m.styles = styles
+	}
+}
+
+// Model is the notification manager. It must receive all the
+// messages of the application in order to catch the notification
+// requests.
+type Model struct {
+	width, height int
+
+	visible []Notification
+	queue   []Notification
+	history []Notification
+	nextID  int
+
+	maxVisible  int
+	historySize int
+
+	histories []*History
+
+	overlay  *overlay.Model
+	position overlay.Position
+	styles   *Styles
+}
+
+// New creates a new toast manager with optional configurations.
+func New(opts ...Option) *Model {
+	m := &Model{
+		maxVisible:  5,
+		historySize: 100,
+		position:    overlay.Offset(overlay.Align(lipgloss.Right, lipgloss.Bottom), -1, -1),
+		styles:      DefaultStyles(),
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Init initializes the toast manager.
+func (m *Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles notification requests and dismisses toasts when
+// their timeout expires.
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case Msg:
+		return m, m.add(msg)
+	case dismissMsg:
+		return m, m.Dismiss(msg.id)
+	}
+
+	return m, nil
+}
+
+// Visible returns the toasts currently shown.
+func (m *Model) Visible() []Notification {
+	return m.visible
+}
+
+// History returns the past notifications, from the oldest to the
+// newest.
+func (m *Model) History() []Notification {
+	return m.history
+}
+
+// ClearHistory empties the history.
+func (m *Model) ClearHistory() {
+	m.history = nil
+
+	m.refreshHistories()
+}
+
+// Dismiss removes the toast with the given ID, showing the next
+// queued one if any.
+func (m *Model) Dismiss(id int) tea.Cmd {
+	for i, n := range m.visible {
+		if n.ID == id {
+			m.visible = append(m.visible[:i], m.visible[i+1:]...)
+			break
+		}
+	}
+
+	var cmd tea.Cmd
+
+	if len(m.queue) > 0 && len(m.visible) < m.maxVisible {
+		next := m.queue[0]
+		m.queue = m.queue[1:]
+		cmd = m.show(next)
+	}
+
+	m.updateOverlay()
+
+	return cmd
+}
+
+// DismissAll removes all the toasts, including the queued ones.
+func (m *Model) DismissAll() {
+	m.visible = nil
+	m.queue = nil
+
+	m.updateOverlay()
+}
+
+// View renders the visible toasts stacked vertically, the newest at
+// the bottom.
+func (m *Model) View() string {
+	toasts := make([]string, 0)
+
+	for _, n := range m.visible {
+		toasts = append(toasts, m.styles.Toast.Copy().Inherit(m.levelStyle(n.Level)).Render(n.Text))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Right, toasts...)
+}
+
+// GetWidth returns the current width of the toast stack.
+func (m *Model) GetWidth() int { return lipgloss.Width(m.View()) }
+
+// GetHeight returns the current height of the toast stack.
+func (m *Model) GetHeight() int { return lipgloss.Height(m.View()) }
+
+func (m *Model) SetWidth(width int) { m.width = width }
+
+func (m *Model) SetHeight(height int) { m.height = height }
+
+func (m *Model) SetSize(width int, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m *Model) CanGrow() bool {
+	return false
+}
+
+func (m *Model) add(msg Msg) tea.Cmd {
+	n := Notification{
+		ID:      m.nextID,
+		Level:   msg.Level,
+		Text:    msg.Text,
+		Time:    time.Now(),
+		Timeout: msg.Timeout,
+	}
+
+	if n.Timeout == 0 {
+		n.Timeout = DefaultTimeout
+	}
+
+	m.nextID++
+
+	m.history = append(m.history, n)
+	if len(m.history) > m.historySize {
+		m.history = m.history[len(m.history)-m.historySize:]
+	}
+
+	m.refreshHistories()
+
+	if len(m.visible) >= m.maxVisible {
+		m.queue = append(m.queue, n)
+		return nil
+	}
+
+	cmd := m.show(n)
+
+	m.updateOverlay()
+
+	return cmd
+}
+
+func (m *Model) show(n Notification) tea.Cmd {
+	m.visible = append(m.visible, n)
+
+	if n.Timeout < 0 {
+		return nil
+	}
+
+	return tea.Tick(n.Timeout, func(time.Time) tea.Msg {
+		return dismissMsg{n.ID}
+	})
+}
+
+// updateOverlay keeps the manager on the overlay only while there are
+// toasts to show.
+func (m *Model) updateOverlay() {
+	if m.overlay == nil {
+		return
+	}
+
+	m.overlay.Remove(m)
+
+	if len(m.visible) > 0 {
+		m.overlay.Push(m, m.position)
+	}
+}
+
+func (m *Model) refreshHistories() {
+	for _, h := range m.histories {
+		h.refresh()
+	}
+}
+
+func (m *Model) levelStyle(level Level) lipgloss.Style {
+	switch level {
+	case SuccessLevel:
+		return m.styles.Success
+	case WarningLevel:
+		return m.styles.Warning
+	case ErrorLevel:
+		return m.styles.Error
+	}
+	return m.styles.Info
+}
+
+// format renders a notification as a line of the history.
+func format(n Notification) string {
+	return fmt.Sprintf("%s [%s] %s", n.Time.Format("15:04:05"), n.Level, n.Text)
+}
@@ -0,0 +1,34 @@
+package toast
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNotifyFor(t *testing.T) {
+	m := New()
+
+	_, cmd := m.Update(NotifyFor(InfoLevel, "sticky", -1)())
+	if cmd != nil {
+		t.Error("a toast with a negative timeout is dismissed")
+	}
+
+	m.Update(NotifyFor(InfoLevel, "quick", time.Second)())
+
+	if got := m.Visible()[1].Timeout; got != time.Second {
+		t.Errorf("toast timeout is %v, want 1s", got)
+	}
+}
+
+func TestHistoryRefresh(t *testing.T) {
+	m := New()
+	h := NewHistory(m)
+	h.SetSize(40, 5)
+
+	m.Update(Msg{Text: "hello"})
+
+	if view := h.View(); !strings.Contains(view, "hello") {
+		t.Errorf("history view %q doesn't show the new notification", view)
+	}
+}
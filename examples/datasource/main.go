@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	btTable "github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	foam "github.com/remogatto/sugarfoam"
+	"github.com/remogatto/sugarfoam/components/header"
+	"github.com/remogatto/sugarfoam/components/statusbar"
+	"github.com/remogatto/sugarfoam/components/table"
+	"github.com/remogatto/sugarfoam/layout"
+)
+
+const numRows = 1000000
+
+// source simulates a slow paginated API serving a million rows.
+type source struct{}
+
+func (s source) Len() int {
+	return numRows
+}
+
+// Row returns nil as no row is available until it is fetched.
+func (s source) Row(i int) btTable.Row {
+	return nil
+}
+
+func (s source) Fetch(r table.Range) ([]btTable.Row, error) {
+	time.Sleep(100 * time.Millisecond)
+
+	rows := make([]btTable.Row, 0, r.End-r.Start)
+	for i := r.Start; i < r.End; i++ {
+		rows = append(rows, btTable.Row{
+			fmt.Sprintf("%d", i+1),
+			fmt.Sprintf("Item #%d", i+1),
+		})
+	}
+
+	return rows, nil
+}
+
+type model struct {
+	table     *table.Model
+	statusBar *statusbar.Model
+
+	document *layout.Layout
+
+	bindings *keyBindings
+}
+
+type keyBindings struct {
+	table *table.Model
+
+	quit key.Binding
+}
+
+func (k *keyBindings) ShortHelp() []key.Binding {
+	return []key.Binding{
+		k.table.KeyMap.LineUp,
+		k.table.KeyMap.LineDown,
+		k.table.KeyMap.PageDown,
+		k.quit,
+	}
+}
+
+func (k keyBindings) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{
+			k.quit,
+		},
+	}
+}
+
+func newBindings(t *table.Model) *keyBindings {
+	return &keyBindings{
+		table: t,
+		quit: key.NewBinding(
+			key.WithKeys("esc"), key.WithHelp("esc", "Quit app"),
+		),
+	}
+}
+
+func initialModel() model {
+	table := table.New(
+		table.WithRelWidths(30, 70),
+		table.WithDataSource(source{}),
+		table.WithPageSize(50),
+	)
+	table.SetColumns([]btTable.Column{
+		{Title: "#", Width: 10},
+		{Title: "Item", Width: 20},
+	})
+
+	bindings := newBindings(table)
+	statusBar := statusbar.New(bindings, statusbar.WithContent("Idle", "", "ONLINE"))
+
+	header := header.New(
+		header.WithContent(
+			lipgloss.NewStyle().Bold(true).Border(lipgloss.NormalBorder(), false, false, true, false).Render("🧋Sugarfoam DataSource Example🧋"),
+		),
+	)
+
+	document := layout.New(
+		layout.WithStyles(&layout.Styles{Container: lipgloss.NewStyle().Padding(1)}),
+		layout.WithItem(header),
+		layout.WithItem(table),
+		layout.WithItem(statusBar),
+	)
+
+	return model{
+		table:     table,
+		statusBar: statusBar,
+		document:  document,
+		bindings:  bindings,
+	}
+}
+
+func (m model) Init() tea.Cmd {
+	m.table.Focus()
+
+	return m.table.Init()
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.document.SetSize(msg.Width, msg.Height)
+	case tea.KeyMsg:
+		if key.Matches(msg, m.bindings.quit) {
+			return m, tea.Quit
+		}
+	case tea.MouseMsg:
+		// Mouse coordinates are relative to the screen, make them
+		// relative to the table.
+		r, _ := layout.Locate(m.document, m.table)
+		msg = foam.LocalMouse(msg, r)
+	case table.FetchErrorMsg:
+		m.statusBar.SetContent("Error", msg.Err.Error(), "OFFLINE")
+		return m, nil
+	}
+
+	_, cmd := m.table.Update(msg)
+
+	m.statusBar.SetContent(
+		"Browsing",
+		fmt.Sprintf("Row %d of %d", m.table.Cursor()+1, m.table.DataSource().Len()),
+		"ONLINE",
+	)
+
+	return m, cmd
+}
+
+func (m model) View() string {
+	return m.document.View()
+}
+
+func main() {
+	if len(os.Getenv("DEBUG")) > 0 {
+		f, err := tea.LogToFile("debug.log", "debug")
+		if err != nil {
+			fmt.Println("fatal:", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+	}
+
+	if _, err := tea.NewProgram(initialModel(), tea.WithMouseCellMotion()).Run(); err != nil {
+		fmt.Println("Error running program:", err)
+		os.Exit(1)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/lipgloss"
+	foam "github.com/remogatto/sugarfoam"
 	"github.com/remogatto/sugarfoam/components/group"
 	"github.com/remogatto/sugarfoam/components/header"
 	"github.com/remogatto/sugarfoam/components/image"
@@ -84,6 +85,8 @@ func (k *keyBindings) ShortHelp() []key.Binding {
 			keys,
 			currItem.KeyMap.LineDown,
 			currItem.KeyMap.LineUp,
+			currItem.Keys.Sort,
+			currItem.Keys.Filter,
 		)
 	}
 
@@ -120,8 +123,11 @@ func newBindings(group *group.Model) *keyBindings {
 func initialModel() model {
 	viewport := viewport.New()
 
-	table := table.New(table.WithRelWidths(30, 70))
-	table.Model.SetColumns([]btTable.Column{
+	table := table.New(
+		table.WithRelWidths(30, 70),
+		table.WithRowKey(func(row btTable.Row) string { return row[0] }),
+	)
+	table.SetColumns([]btTable.Column{
 		{Title: "ID", Width: 20},
 		{Title: "Name", Width: 10},
 	})
@@ -202,6 +208,13 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds []tea.Cmd
 	)
 
+	if mouse, ok := msg.(tea.MouseMsg); ok {
+		// Mouse coordinates are relative to the screen, make them
+		// relative to the group.
+		r, _ := layout.Locate(m.document, m.group)
+		msg = foam.LocalMouse(mouse, r)
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.handleWindowSize(msg)
@@ -224,7 +237,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	cmds = append(cmds, cmd)
 
 	if m.state == BrowseState {
-		currRow := m.table.Model.Cursor() + 1
+		currRow := m.table.Cursor() + 1
 		m.statusBar.SetContent(formats[BrowseState][0],
 			fmt.Sprintf(formats[BrowseState][1], currRow, len(m.characters)),
 			formats[BrowseState][2],
@@ -271,7 +284,8 @@ func (m *model) createRenderer(width int) *glamour.TermRenderer {
 }
 
 func (m *model) handleKeyMsg(msg tea.KeyMsg, cmds []tea.Cmd) []tea.Cmd {
-	if key.Matches(msg, m.bindings.quit) {
+	// Esc clears the table filter while it's being edited.
+	if key.Matches(msg, m.bindings.quit) && !m.table.Filtering() {
 		return append(cmds, tea.Quit)
 	}
 	return cmds
@@ -297,13 +311,16 @@ func (m model) View() string {
 
 // updateViewport updates the viewport with the selected character's details.
 func (m model) updateViewport() tea.Cmd {
-	if m.table.Cursor() >= 0 {
-		if m.table.Cursor() >= len(m.characters)-1 {
+	if row := m.table.SelectedRow(); row != nil {
+		// The next page is loaded when the cursor reaches the last
+		// row, unless the filter hides the rows of the next page
+		// anyway.
+		if m.table.Filter() == "" && m.table.Cursor() >= len(m.table.Rows())-1 {
 			m.api.page++
 			return m.api.checkConnection
 		}
 
-		character := m.characters[m.table.Cursor()]
+		character := m.characterByID(row[0])
 		md, _ := m.renderer.Render(
 			fmt.Sprintf(
 				characterTpl,
@@ -318,6 +335,17 @@ func (m model) updateViewport() tea.Cmd {
 	return nil
 }
 
+// characterByID returns the character with the given ID.
+func (m model) characterByID(id string) character {
+	for _, c := range m.characters {
+		if c.ID == id {
+			return c
+		}
+	}
+
+	return character{}
+}
+
 // setTableRows sets the table rows with the provided character data.
 func (m *model) updateTableRows(data []character) {
 	for _, c := range data {
@@ -330,7 +358,7 @@ func (m *model) updateTableRows(data []character) {
 		rows = append(rows, btTable.Row{character.ID, sanitize(character.Name)})
 	}
 
-	m.table.Model.SetRows(rows)
+	m.table.SetRows(rows)
 }
 
 func sanitize(text string) string {
@@ -351,7 +379,7 @@ func main() {
 		defer f.Close()
 	}
 
-	if _, err := tea.NewProgram(initialModel() /*, tea.WithAltScreen()*/).Run(); err != nil {
+	if _, err := tea.NewProgram(initialModel(), tea.WithMouseCellMotion() /*, tea.WithAltScreen()*/).Run(); err != nil {
 		fmt.Println("Error running program:", err)
 		os.Exit(1)
 	}
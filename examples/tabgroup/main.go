@@ -46,11 +46,13 @@ func (k *keyBindings) ShortHelp() []key.Binding {
 
 	switch tabItem := currentTabItem.(type) {
 	case *tabitem.Model:
-		keys = append(
-			keys,
-			tabItem.KeyMap.FocusNext,
-			tabItem.KeyMap.FocusPrev,
-		)
+		if g, ok := tabItem.Content().(*group.Model); ok {
+			keys = append(
+				keys,
+				g.KeyMap.FocusNext,
+				g.KeyMap.FocusPrev,
+			)
+		}
 
 		switch model := tabItem.Current().(type) {
 		case *table.Model:
@@ -181,6 +183,13 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 
 		}
+	case tea.MouseMsg:
+		// Mouse coordinates are relative to the screen, make them
+		// relative to the tab group.
+		r, _ := layout.Locate(m.document, m.tabGroup)
+		_, cmd := m.tabGroup.Update(foam.LocalMouse(msg, r))
+
+		return m, cmd
 	}
 
 	_, cmd := m.tabGroup.Update(msg)
@@ -204,7 +213,7 @@ func main() {
 		defer f.Close()
 	}
 
-	if _, err := tea.NewProgram(initialModel() /*tea.WithAltScreen()*/).Run(); err != nil {
+	if _, err := tea.NewProgram(initialModel(), tea.WithMouseCellMotion() /*, tea.WithAltScreen()*/).Run(); err != nil {
 		fmt.Println("Error running program:", err)
 		os.Exit(1)
 	}
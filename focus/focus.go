@@ -0,0 +1,342 @@
+package focus
+
+// Package focus provides a focus manager handling the focus of a
+// whole tree of focusable elements. The manager walks the tree
+// through the foam.Container interface (implemented by groups and tab
+// groups) and gives Tab/Shift+Tab a global order across all the
+// nested containers, instead of cycling the direct items of a single
+// group.
+//
+// The manager wraps the root of the tree: the application forwards
+// messages to the manager, which handles the focus key bindings and
+// passes everything else to the root.
+//
+// Optionally, the manager can move the focus spatially, to the
+// element physically on the left, right, top or bottom of the focused
+// one. The position of the elements is taken from the areas the
+// layouts assigned to them.
+//
+// The spatial keys alt+left and alt+right are also the keys switching
+// tab in a tab group. The manager handles them first, so they move
+// the focus across the panes of the active tab, and passes them to
+// the tab group only when there is no pane in that direction: at the
+// edge of the tab they switch to the next or previous tab.
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	foam "github.com/remogatto/sugarfoam"
+)
+
+// FocusChangedMsg is emitted when the focus moves from one element to
+// another, either through the manager or through the containers of
+// the tree, e.g. by clicking an element or switching tab. From is nil
+// when the first element is focused.
+type FocusChangedMsg struct {
+	From, To foam.Focusable
+}
+
+// Skipper is an interface for focusable elements that may refuse
+// the focus, e.g. because they are disabled.
+type Skipper interface {
+	SkipFocus() bool
+}
+
+// Option is a type for functions that modify a focus manager.
+type Option func(*Manager)
+
+// WithKeyMap sets the key bindings of the manager.
+func WithKeyMap(km KeyMap) Option {
+	return func(m *Manager) {
+		m.KeyMap = km
+	}
+}
+
+// WithSpatial enables the spatial navigation key bindings. When there
+// is no element in the requested direction the key message is
+// forwarded to the root as usual.
+func WithSpatial(enabled bool) Option {
+	return func(m *Manager) {
+		m.spatial = enabled
+	}
+}
+
+// KeyMap defines the key bindings of the focus manager.
+type KeyMap struct {
+	FocusNext  key.Binding
+	FocusPrev  key.Binding
+	FocusLeft  key.Binding
+	FocusRight key.Binding
+	FocusUp    key.Binding
+	FocusDown  key.Binding
+}
+
+// DefaultKeyMap returns the default key bindings of the focus
+// manager.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		FocusNext: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "focus next"),
+		),
+		FocusPrev: key.NewBinding(
+			key.WithKeys("shift+tab"),
+			key.WithHelp("shift+tab", "focus prev"),
+		),
+		FocusLeft: key.NewBinding(
+			key.WithKeys("alt+left"),
+			key.WithHelp("alt+←", "focus left"),
+		),
+		FocusRight: key.NewBinding(
+			key.WithKeys("alt+right"),
+			key.WithHelp("alt+→", "focus right"),
+		),
+		FocusUp: key.NewBinding(
+			key.WithKeys("alt+up"),
+			key.WithHelp("alt+↑", "focus up"),
+		),
+		FocusDown: key.NewBinding(
+			key.WithKeys("alt+down"),
+			key.WithHelp("alt+↓", "focus down"),
+		),
+	}
+}
+
+// Manager handles the focus of a tree of focusable elements.
+type Manager struct {
+	KeyMap KeyMap
+
+	root    foam.Focusable
+	current foam.Focusable
+	spatial bool
+}
+
+// New creates a new focus manager for the tree rooted at root.
+func New(root foam.Focusable, opts ...Option) *Manager {
+	m := &Manager{
+		KeyMap: DefaultKeyMap(),
+		root:   root,
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Root returns the root of the managed tree.
+func (m *Manager) Root() foam.Focusable {
+	return m.root
+}
+
+// Init initializes the root and focuses the first element of the
+// tree.
+func (m *Manager) Init() tea.Cmd {
+	cmd := m.root.Init()
+
+	leaves := m.Leaves()
+	if len(leaves) == 0 {
+		return cmd
+	}
+
+	return tea.Batch(cmd, m.Focus(leaves[0]))
+}
+
+// Update handles the focus key bindings and forwards any other
+// message to the root, reporting the focus changes made by the
+// containers of the tree.
+func (m *Manager) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, m.KeyMap.FocusNext):
+			return m, m.Next()
+		case key.Matches(msg, m.KeyMap.FocusPrev):
+			return m, m.Prev()
+		}
+
+		if m.spatial {
+			dirs := map[Direction]key.Binding{
+				Left:  m.KeyMap.FocusLeft,
+				Right: m.KeyMap.FocusRight,
+				Up:    m.KeyMap.FocusUp,
+				Down:  m.KeyMap.FocusDown,
+			}
+
+			for dir, binding := range dirs {
+				if !key.Matches(msg, binding) {
+					continue
+				}
+				if cmd, ok := m.Move(dir); ok {
+					return m, cmd
+				}
+			}
+		}
+	}
+
+	from := m.Current()
+
+	_, cmd := m.root.Update(msg)
+
+	if to := m.Current(); to != nil && to != from {
+		m.current = to
+		cmd = tea.Batch(cmd, func() tea.Msg {
+			return FocusChangedMsg{From: from, To: to}
+		})
+	}
+
+	return m, cmd
+}
+
+// View renders the root.
+func (m *Manager) View() string {
+	return m.root.View()
+}
+
+// Leaves returns the focusable elements of the tree in focus order,
+// i.e. the elements that don't hold other elements, excluding those
+// refusing the focus.
+func (m *Manager) Leaves() []foam.Focusable {
+	return leaves(m.root, make([]foam.Focusable, 0))
+}
+
+// Current returns the focused element.
+func (m *Manager) Current() foam.Focusable {
+	// The focus may have been moved without the manager knowing, e.g.
+	// by switching tab or clicking an element: follow the current
+	// elements of the containers when possible.
+	if a := active(m.root); a != nil {
+		return a
+	}
+
+	if m.current != nil && m.indexOf(m.current) >= 0 {
+		return m.current
+	}
+
+	return nil
+}
+
+// Next moves the focus to the next element.
+func (m *Manager) Next() tea.Cmd {
+	return m.move(1)
+}
+
+// Prev moves the focus to the previous element.
+func (m *Manager) Prev() tea.Cmd {
+	return m.move(-1)
+}
+
+// Focus moves the focus to target, making it the current element of
+// all the containers on its path.
+func (m *Manager) Focus(target foam.Focusable) tea.Cmd {
+	path := pathTo(m.root, target)
+	if path == nil {
+		return nil
+	}
+
+	from := m.Current()
+
+	// Blurring and focusing the root lets the containers on the old
+	// and the new path update their own focus state as well.
+	m.root.Blur()
+
+	for i := 0; i < len(path)-1; i++ {
+		if s, ok := path[i].(interface{ SetCurrent(foam.Focusable) }); ok {
+			s.SetCurrent(path[i+1])
+		}
+	}
+
+	m.current = target
+
+	cmd := m.root.Focus()
+
+	if from == target {
+		return cmd
+	}
+
+	return tea.Batch(cmd, func() tea.Msg {
+		return FocusChangedMsg{From: from, To: target}
+	})
+}
+
+func (m *Manager) move(delta int) tea.Cmd {
+	leaves := m.Leaves()
+	if len(leaves) == 0 {
+		return nil
+	}
+
+	i := m.indexOf(m.Current())
+	if i < 0 {
+		return m.Focus(leaves[0])
+	}
+
+	i = (i + delta + len(leaves)) % len(leaves)
+
+	return m.Focus(leaves[i])
+}
+
+func (m *Manager) indexOf(f foam.Focusable) int {
+	for i, leaf := range m.Leaves() {
+		if leaf == f {
+			return i
+		}
+	}
+	return -1
+}
+
+func leaves(node foam.Focusable, acc []foam.Focusable) []foam.Focusable {
+	if s, ok := node.(Skipper); ok && s.SkipFocus() {
+		return acc
+	}
+
+	if c, ok := node.(foam.Container); ok {
+		for _, child := range c.Children() {
+			acc = leaves(child, acc)
+		}
+		return acc
+	}
+
+	return append(acc, node)
+}
+
+// pathTo returns the elements from node down to target, or nil if
+// target can't be reached.
+func pathTo(node, target foam.Focusable) []foam.Focusable {
+	if node == target {
+		return []foam.Focusable{node}
+	}
+
+	if c, ok := node.(foam.Container); ok {
+		for _, child := range c.Children() {
+			if path := pathTo(child, target); path != nil {
+				return append([]foam.Focusable{node}, path...)
+			}
+		}
+	}
+
+	return nil
+}
+
+// active follows the current element of the containers down from
+// node and returns the leaf it reaches. It returns nil if a container
+// on the way doesn't tell which of its children is the current one.
+func active(node foam.Focusable) foam.Focusable {
+	c, ok := node.(foam.Container)
+	if !ok {
+		return node
+	}
+
+	children := c.Children()
+	if len(children) == 0 {
+		return nil
+	}
+
+	if g, ok := node.(foam.Groupable); ok {
+		return active(g.Current())
+	}
+
+	if len(children) == 1 {
+		return active(children[0])
+	}
+
+	return nil
+}
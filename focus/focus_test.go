@@ -0,0 +1,184 @@
+package focus
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/remogatto/sugarfoam/components/group"
+	"github.com/remogatto/sugarfoam/components/tabgroup"
+	"github.com/remogatto/sugarfoam/components/tabgroup/tabitem"
+	"github.com/remogatto/sugarfoam/internal/foamtest"
+	"github.com/remogatto/sugarfoam/layout"
+	"github.com/remogatto/sugarfoam/layout/tiled"
+)
+
+// focusChanges runs cmd and returns the focus changes it reports.
+func focusChanges(cmd tea.Cmd) []FocusChangedMsg {
+	if cmd == nil {
+		return nil
+	}
+
+	switch msg := cmd().(type) {
+	case FocusChangedMsg:
+		return []FocusChangedMsg{msg}
+	case tea.BatchMsg:
+		var changes []FocusChangedMsg
+		for _, cmd := range msg {
+			changes = append(changes, focusChanges(cmd)...)
+		}
+		return changes
+	}
+
+	return nil
+}
+
+func TestFocusChangedOnClick(t *testing.T) {
+	first, second := foamtest.NewField("first"), foamtest.NewField("second")
+	g := group.New(
+		group.WithItems(first, second),
+		group.WithLayout(layout.New(
+			layout.WithStyles(&layout.Styles{}),
+			layout.WithItem(first),
+			layout.WithItem(second),
+		)),
+	)
+
+	m := New(g)
+	m.Init()
+	g.SetSize(20, 10)
+
+	r, ok := layout.Locate(g, second)
+	if !ok {
+		t.Fatal("the second field is not placed")
+	}
+
+	_, cmd := m.Update(tea.MouseMsg{
+		X:      r.X,
+		Y:      r.Y,
+		Action: tea.MouseActionPress,
+		Button: tea.MouseButtonLeft,
+	})
+
+	changes := focusChanges(cmd)
+	if len(changes) != 1 || changes[0].From != first || changes[0].To != second {
+		t.Errorf("focus changes = %v, want one from the first to the second field", changes)
+	}
+}
+
+func TestFocusChangedOnTabSwitch(t *testing.T) {
+	first, second := foamtest.NewField("first"), foamtest.NewField("second")
+	tg := tabgroup.New(tabgroup.WithItems(
+		tabitem.New(first, tabitem.WithTitle("First"), tabitem.WithActive(true)),
+		tabitem.New(second, tabitem.WithTitle("Second")),
+	))
+
+	m := New(tg)
+	m.Init()
+	tg.SetSize(20, 10)
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRight, Alt: true})
+
+	changes := focusChanges(cmd)
+	if len(changes) != 1 || changes[0].From != first || changes[0].To != second {
+		t.Errorf("focus changes = %v, want one from the first to the second field", changes)
+	}
+}
+
+func TestSpatialWithTabGroup(t *testing.T) {
+	left, right, other := foamtest.NewField("left"), foamtest.NewField("right"), foamtest.NewField("other")
+	g := group.New(
+		group.WithItems(left, right),
+		group.WithLayout(layout.New(
+			layout.WithStyles(&layout.Styles{}),
+			layout.WithItem(tiled.New(left, right)),
+		)),
+	)
+	tg := tabgroup.New(tabgroup.WithItems(
+		tabitem.New(g, tabitem.WithTitle("Tiled"), tabitem.WithActive(true)),
+		tabitem.New(other, tabitem.WithTitle("Other")),
+	))
+
+	m := New(tg, WithSpatial(true))
+	m.Init()
+	tg.SetSize(40, 10)
+
+	altRight := tea.KeyMsg{Type: tea.KeyRight, Alt: true}
+	altLeft := tea.KeyMsg{Type: tea.KeyLeft, Alt: true}
+
+	m.Update(altRight)
+
+	if m.Current() != right {
+		t.Fatalf("alt+right focused %v, want the right field", m.Current())
+	}
+
+	m.Update(altRight)
+
+	if m.Current() != other {
+		t.Fatalf("alt+right at the edge of the tab focused %v, want the field of the next tab", m.Current())
+	}
+
+	m.Update(altLeft)
+
+	if m.Current() != right {
+		t.Fatalf("alt+left at the edge of the tab focused %v, want the right field of the previous tab", m.Current())
+	}
+
+	m.Update(altLeft)
+
+	if m.Current() != left {
+		t.Errorf("alt+left focused %v, want the left field", m.Current())
+	}
+}
+
+// disabled is a field refusing the focus.
+type disabled struct {
+	*foamtest.Field
+}
+
+func (disabled) SkipFocus() bool { return true }
+
+func TestGlobalOrder(t *testing.T) {
+	a, b, c := foamtest.NewField("a"), foamtest.NewField("b"), foamtest.NewField("c")
+	skipped := disabled{foamtest.NewField("skipped")}
+
+	inner := group.New(
+		group.WithItems(b, skipped, c),
+		group.WithLayout(layout.New(layout.WithStyles(&layout.Styles{}), layout.WithItem(b), layout.WithItem(skipped), layout.WithItem(c))),
+	)
+	outer := group.New(
+		group.WithItems(a, inner),
+		group.WithLayout(layout.New(layout.WithStyles(&layout.Styles{}), layout.WithItem(a), layout.WithItem(inner))),
+	)
+
+	m := New(outer)
+	m.Init()
+
+	if m.Current() != a || !a.HasFocus {
+		t.Fatalf("Init focused %v, want a", m.Current())
+	}
+
+	var order []string
+	for i := 0; i < 3; i++ {
+		cmd := m.Next()
+		order = append(order, m.Current().(*foamtest.Field).Name)
+
+		changes := focusChanges(cmd)
+		if len(changes) != 1 || changes[0].To != m.Current() {
+			t.Errorf("focus changes = %v, want one to %v", changes, m.Current())
+		}
+	}
+
+	if got := strings.Join(order, " "); got != "b c a" {
+		t.Errorf("focus order is %q, want %q", got, "b c a")
+	}
+	if skipped.HasFocus {
+		t.Error("the element refusing the focus was focused")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+
+	if m.Current() != c || !c.HasFocus || a.HasFocus {
+		t.Errorf("shift+tab focused %v, want c", m.Current())
+	}
+}
@@ -0,0 +1,101 @@
+package focus
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	foam "github.com/remogatto/sugarfoam"
+	"github.com/remogatto/sugarfoam/layout"
+)
+
+// Direction is the direction of a spatial focus move.
+type Direction int
+
+const (
+	Left Direction = iota
+	Right
+	Up
+	Down
+)
+
+// Move moves the focus to the nearest element in the given
+// direction, according to the areas assigned to the elements by the
+// layouts (see layout.Locate). It returns false if there is no
+// element in that direction.
+func (m *Manager) Move(dir Direction) (tea.Cmd, bool) {
+	target := m.neighbor(dir)
+	if target == nil {
+		return nil, false
+	}
+
+	return m.Focus(target), true
+}
+
+// neighbor returns the element nearest to the current one in the
+// given direction. Elements aligned with the current one are
+// preferred over closer but misaligned ones.
+func (m *Manager) neighbor(dir Direction) foam.Focusable {
+	current := m.Current()
+	if current == nil {
+		return nil
+	}
+
+	from, ok := layout.Locate(m.root, current)
+	if !ok {
+		return nil
+	}
+
+	var (
+		best      foam.Focusable
+		bestScore int
+	)
+
+	for _, leaf := range m.Leaves() {
+		if leaf == current {
+			continue
+		}
+
+		to, ok := layout.Locate(m.root, leaf)
+		if !ok {
+			continue
+		}
+
+		var distance, misalignment int
+
+		switch dir {
+		case Left:
+			distance = from.X - (to.X + to.W)
+			misalignment = gap(from.Y, from.H, to.Y, to.H)
+		case Right:
+			distance = to.X - (from.X + from.W)
+			misalignment = gap(from.Y, from.H, to.Y, to.H)
+		case Up:
+			distance = from.Y - (to.Y + to.H)
+			misalignment = gap(from.X, from.W, to.X, to.W)
+		case Down:
+			distance = to.Y - (from.Y + from.H)
+			misalignment = gap(from.X, from.W, to.X, to.W)
+		}
+
+		if distance < 0 {
+			continue
+		}
+
+		score := distance + 2*misalignment
+		if best == nil || score < bestScore {
+			best, bestScore = leaf, score
+		}
+	}
+
+	return best
+}
+
+// gap returns the distance between the segments starting at a and b,
+// zero if they overlap.
+func gap(a, aLen, b, bLen int) int {
+	switch {
+	case b >= a+aLen:
+		return b - (a + aLen)
+	case a >= b+bLen:
+		return a - (b + bLen)
+	}
+	return 0
+}
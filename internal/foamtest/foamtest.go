@@ -0,0 +1,70 @@
+package foamtest
+
+// Package foamtest provides stub placeables and focusables for the
+// tests of the layouts and of the containers. It depends on Bubble Tea
+// only, so that any package of the module can use it in its tests.
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Box is a placeable rendered as a block of Width x Height cells. A
+// growable box takes the size it is given, the others keep their own
+// size, like the components sized by their content.
+type Box struct {
+	Width, Height int
+	Grow          bool
+}
+
+func (b *Box) SetSize(width int, height int) {
+	if b.Grow {
+		b.Width, b.Height = width, height
+	}
+}
+
+func (b *Box) SetWidth(width int)   { b.SetSize(width, b.Height) }
+func (b *Box) SetHeight(height int) { b.SetSize(b.Width, height) }
+func (b *Box) GetWidth() int        { return b.Width }
+func (b *Box) GetHeight() int       { return b.Height }
+func (b *Box) CanGrow() bool        { return b.Grow }
+
+func (b *Box) View() string {
+	line := strings.Repeat(" ", b.Width)
+	lines := make([]string, b.Height)
+	for i := range lines {
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Field is a growable focusable box, recording the messages it
+// receives.
+type Field struct {
+	Box
+
+	Name     string
+	HasFocus bool
+	Msgs     []tea.Msg
+}
+
+// NewField returns a growable field called name.
+func NewField(name string) *Field {
+	return &Field{Box: Box{Grow: true}, Name: name}
+}
+
+func (f *Field) Init() tea.Cmd { return nil }
+
+func (f *Field) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	f.Msgs = append(f.Msgs, msg)
+	return f, nil
+}
+
+func (f *Field) Focus() tea.Cmd {
+	f.HasFocus = true
+	return nil
+}
+
+func (f *Field) Blur() { f.HasFocus = false }
+
+func (f *Field) String() string { return f.Name }
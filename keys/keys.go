@@ -3,6 +3,7 @@ package keys
 import (
 	"fmt"
 	"reflect"
+	"sort"
 
 	"github.com/charmbracelet/bubbles/key"
 )
@@ -61,6 +62,15 @@ func (kb *Bindings) GetKey(name string) key.Binding {
 	return kb.bindings[name]
 }
 
+func (kb *Bindings) Names() []string {
+	names := make([]string, 0)
+	for name := range kb.bindings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func (kb *Bindings) SetKey(name string, key key.Binding) {
 	kb.bindings[name] = key
 }
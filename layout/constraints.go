@@ -0,0 +1,211 @@
+package layout
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Constraints describes how a placeable wants to be sized along the
+// main axis of a layout when the constraint solver is enabled.
+//
+// Min is the smallest acceptable size and Max the largest one, with
+// zero meaning no maximum. Preferred is the size the item would like
+// to have. Priority decides which items get the available space
+// first: items with a higher priority reach their preferred size
+// before items with a lower priority.
+type Constraints struct {
+	Min, Max, Preferred, Priority int
+}
+
+// Constrained is an interface for placeables that declare their own
+// sizing constraints.
+type Constrained interface {
+	Constraints() Constraints
+}
+
+// UnsatisfiableError is returned by Solve when the constraints can't
+// be satisfied within the available space.
+type UnsatisfiableError struct {
+	Available, Required int
+	Reason              string
+}
+
+func (e *UnsatisfiableError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("layout: unsatisfiable constraints: %s", e.Reason)
+	}
+	return fmt.Sprintf("layout: unsatisfiable constraints: %d required, %d available", e.Required, e.Available)
+}
+
+// constrained wraps a placeable with the constraints given to
+// Constrain.
+type constrained struct {
+	Placeable
+
+	constraints   Constraints
+	width, height int
+}
+
+func (c *constrained) Constraints() Constraints {
+	return c.constraints
+}
+
+// Unwrap returns the wrapped item.
+func (c *constrained) Unwrap() Placeable {
+	return c.Placeable
+}
+
+// SetSize sets the dimensions of the wrapper and of the wrapped item.
+func (c *constrained) SetSize(width int, height int) {
+	c.width = width
+	c.height = height
+
+	c.Placeable.SetSize(width, height)
+}
+
+// Placements returns the wrapped item, which takes the whole area of
+// the wrapper, so that the wrapper doesn't hide it from hit testing.
+func (c *constrained) Placements() []Placement {
+	return []Placement{{Item: c.Placeable, Rect: Rect{X: 0, Y: 0, W: c.width, H: c.height}}}
+}
+
+// Constrain wraps item so that it declares the given constraints to
+// the layout solver. The wrapped item is returned by Unwrap.
+func Constrain(item Placeable, constraints Constraints) Placeable {
+	return &constrained{Placeable: item, constraints: constraints}
+}
+
+// constraintsOf returns the constraints declared by item. Items that
+// don't declare constraints keep their height if they can't grow, or
+// take any size otherwise.
+func constraintsOf(item Placeable) Constraints {
+	if c, ok := item.(Constrained); ok {
+		return c.Constraints()
+	}
+
+	if !item.CanGrow() {
+		h := item.GetHeight()
+		return Constraints{Min: h, Max: h, Preferred: h}
+	}
+
+	return Constraints{}
+}
+
+// Solve splits total among items with the given constraints and
+// returns their sizes. Every item gets at least its minimum size,
+// then the remaining space is given to items in priority order up to
+// their preferred size. What is left is finally shared among all the
+// items up to their maximum size. Space is always split evenly, with
+// the remainder going to the first items.
+//
+// An error is returned if the constraints are inconsistent or if the
+// minimum sizes don't fit in total.
+func Solve(total int, constraints ...Constraints) ([]int, error) {
+	sizes := make([]int, len(constraints))
+	required := 0
+
+	for i, c := range constraints {
+		if c.Min < 0 {
+			return nil, &UnsatisfiableError{Available: total, Reason: fmt.Sprintf("item %d has a negative minimum size", i)}
+		}
+		if c.Max > 0 && c.Min > c.Max {
+			return nil, &UnsatisfiableError{Available: total, Reason: fmt.Sprintf("item %d has a minimum size greater than its maximum size", i)}
+		}
+		sizes[i] = c.Min
+		required += c.Min
+	}
+
+	if required > total {
+		return nil, &UnsatisfiableError{Available: total, Required: required}
+	}
+
+	remaining := total - required
+	priorities := priorityGroups(constraints)
+
+	for _, group := range priorities {
+		remaining = fill(sizes, group, remaining, func(i int) int {
+			c := constraints[i]
+			if c.Max > 0 && c.Preferred > c.Max {
+				return c.Max
+			}
+			return c.Preferred
+		})
+	}
+
+	all := make([]int, len(constraints))
+	for i := range all {
+		all[i] = i
+	}
+
+	fill(sizes, all, remaining, func(i int) int {
+		if constraints[i].Max > 0 {
+			return constraints[i].Max
+		}
+		return -1
+	})
+
+	return sizes, nil
+}
+
+// priorityGroups returns the indices of the constraints grouped by
+// priority, from the highest to the lowest.
+func priorityGroups(constraints []Constraints) [][]int {
+	byPriority := make(map[int][]int)
+	priorities := make([]int, 0)
+
+	for i, c := range constraints {
+		if _, ok := byPriority[c.Priority]; !ok {
+			priorities = append(priorities, c.Priority)
+		}
+		byPriority[c.Priority] = append(byPriority[c.Priority], i)
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(priorities)))
+
+	groups := make([][]int, 0)
+
+	for _, p := range priorities {
+		groups = append(groups, byPriority[p])
+	}
+
+	return groups
+}
+
+// fill evenly grows the sizes of the given items toward their target,
+// a negative target meaning no limit, and returns the space left.
+func fill(sizes []int, items []int, remaining int, target func(int) int) int {
+	for remaining > 0 {
+		open := make([]int, 0)
+
+		for _, i := range items {
+			if t := target(i); t < 0 || sizes[i] < t {
+				open = append(open, i)
+			}
+		}
+
+		if len(open) == 0 {
+			break
+		}
+
+		share := remaining / len(open)
+		if share == 0 {
+			share = 1
+		}
+
+		for _, i := range open {
+			if remaining == 0 {
+				break
+			}
+
+			inc := share
+			if t := target(i); t >= 0 && sizes[i]+inc > t {
+				inc = t - sizes[i]
+			}
+
+			sizes[i] += inc
+			remaining -= inc
+		}
+	}
+
+	return remaining
+}
@@ -0,0 +1,149 @@
+package layout
+
+import (
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Rect represents the area assigned to a placeable, in cells.
+type Rect struct {
+	X, Y, W, H int
+}
+
+// Contains returns true if the point at x, y lies within the
+// rectangle.
+func (r Rect) Contains(x, y int) bool {
+	return x >= r.X && x < r.X+r.W && y >= r.Y && y < r.Y+r.H
+}
+
+// Translate returns the rectangle moved by dx, dy.
+func (r Rect) Translate(dx, dy int) Rect {
+	return Rect{r.X + dx, r.Y + dy, r.W, r.H}
+}
+
+// Placement associates a placeable with the area assigned to it.
+type Placement struct {
+	Item Placeable
+	Rect Rect
+}
+
+// Arranger is an interface for placeables that arrange other
+// placeables and record the area assigned to each of them.
+type Arranger interface {
+	Placeable
+
+	// Placements returns the arranged placeables with the area
+	// assigned to them by the last call to SetSize, relative to the
+	// top-left corner of the arranger.
+	Placements() []Placement
+}
+
+// Positioner is an interface for arrangers that know their absolute
+// position, i.e. the position of their top-left corner on the screen.
+// The position of the root arranger is set by the application (it is
+// 0, 0 by default) and is propagated down to the nested arrangers
+// each time an arranger is sized or moved. Absolute returns the
+// absolute area of the placeables of a positioner.
+type Positioner interface {
+	Arranger
+
+	// SetOrigin moves the arranger to the absolute position x, y.
+	SetOrigin(x, y int)
+
+	// Origin returns the absolute position of the arranger.
+	Origin() (int, int)
+}
+
+// Place moves item to the absolute position x, y. Arrangers that
+// don't record their position pass it on to their own placeables.
+func Place(item Placeable, x, y int) {
+	if p, ok := item.(Positioner); ok {
+		p.SetOrigin(x, y)
+		return
+	}
+
+	if a, ok := item.(Arranger); ok {
+		PlaceChildren(a, x, y)
+	}
+}
+
+// PlaceChildren moves the placeables arranged by a, given the
+// absolute position x, y of a.
+func PlaceChildren(a Arranger, x, y int) {
+	for _, p := range a.Placements() {
+		Place(p.Item, x+p.Rect.X, y+p.Rect.Y)
+	}
+}
+
+// Absolute returns the placements of p with their absolute area,
+// i.e. translated by the origin of p.
+func Absolute(p Positioner) []Placement {
+	x, y := p.Origin()
+
+	placements := make([]Placement, 0)
+
+	for _, placement := range p.Placements() {
+		placements = append(placements, Placement{placement.Item, placement.Rect.Translate(x, y)})
+	}
+
+	return placements
+}
+
+// HitTest returns the innermost placeable drawn at x, y, relative to
+// the top-left corner of root, walking down the tree of arrangers. It
+// returns nil if there is no placeable there.
+func HitTest(root Placeable, x, y int) Placeable {
+	a, ok := root.(Arranger)
+	if !ok {
+		return nil
+	}
+
+	for _, p := range a.Placements() {
+		if !p.Rect.Contains(x, y) {
+			continue
+		}
+
+		if inner := HitTest(p.Item, x-p.Rect.X, y-p.Rect.Y); inner != nil {
+			return inner
+		}
+
+		return p.Item
+	}
+
+	return nil
+}
+
+// Locate returns the area of target relative to the top-left corner
+// of root, walking down the tree of arrangers. It returns false if
+// target can't be found.
+func Locate(root, target Placeable) (Rect, bool) {
+	return locate(root, Rect{0, 0, root.GetWidth(), root.GetHeight()}, target)
+}
+
+func locate(node Placeable, r Rect, target Placeable) (Rect, bool) {
+	if node == target {
+		return r, true
+	}
+
+	arranger, ok := node.(Arranger)
+	if !ok {
+		return Rect{}, false
+	}
+
+	for _, p := range arranger.Placements() {
+		if found, ok := locate(p.Item, p.Rect.Translate(r.X, r.Y), target); ok {
+			return found, true
+		}
+	}
+
+	return Rect{}, false
+}
+
+// FrameOffset returns the horizontal and vertical distance between
+// the outer edge of a block rendered with style and its content,
+// i.e. the left and top margin, border and padding.
+func FrameOffset(style lipgloss.Style) (int, int) {
+	x := style.GetMarginLeft() + style.GetBorderLeftSize() + style.GetPaddingLeft()
+	y := style.GetMarginTop() + style.GetBorderTopSize() + style.GetPaddingTop()
+
+	return x, y
+}
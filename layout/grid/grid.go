@@ -0,0 +1,376 @@
+package grid
+
+// Package grid provides a two-dimensional layout for UI components.
+// Items are placed in the cells of a grid made of column and row
+// tracks and can span several cells. Tracks can have a fixed size, a
+// size fitting their content, or share the remaining space
+// proportionally to a weight. The grid is itself a layout.Placeable,
+// so it can be nested inside a layout.Layout or a group.Model like
+// the tiles of the tiled package.
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/remogatto/sugarfoam/layout"
+)
+
+// DefaultWidth and DefaultHeight define the default dimensions for a
+// grid.
+var (
+	DefaultWidth  = 80
+	DefaultHeight = 25
+)
+
+type trackKind int
+
+const (
+	fixedTrack trackKind = iota
+	frTrack
+	autoTrack
+)
+
+// Track defines the size of a column or a row of the grid.
+type Track struct {
+	kind trackKind
+	size int
+}
+
+// Fixed returns a track of the given size.
+func Fixed(size int) Track {
+	return Track{fixedTrack, size}
+}
+
+// Fr returns a fractional track. The space not taken by fixed and
+// auto tracks is split among fractional tracks proportionally to
+// their weights.
+func Fr(weight int) Track {
+	return Track{frTrack, weight}
+}
+
+// Auto returns a track sized to fit the rendered content of the
+// items placed in it, within the space left by fixed tracks. Growable
+// items, whose content takes whatever size they are given, are
+// measured by their current size instead. Items spanning more than
+// one track are not taken into account.
+func Auto() Track {
+	return Track{autoTrack, 0}
+}
+
+// Option is a type for functions that modify a Grid.
+type Option func(*Grid)
+
+// WithColumns sets the column tracks of the grid.
+func WithColumns(tracks ...Track) Option {
+	return func(g *Grid) {
+		g.columns = tracks
+	}
+}
+
+// WithRows sets the row tracks of the grid.
+func WithRows(tracks ...Track) Option {
+	return func(g *Grid) {
+		g.rows = tracks
+	}
+}
+
+// WithItem places an item in the cell at the given row and column.
+func WithItem(item layout.Placeable, row, col int) Option {
+	return func(g *Grid) {
+		g.AddItem(item, row, col, 1, 1)
+	}
+}
+
+// WithSpan places an item at the given row and column, spanning
+// rowSpan rows and colSpan columns.
+func WithSpan(item layout.Placeable, row, col, rowSpan, colSpan int) Option {
+	return func(g *Grid) {
+		g.AddItem(item, row, col, rowSpan, colSpan)
+	}
+}
+
+type cell struct {
+	item             layout.Placeable
+	row, col         int
+	rowSpan, colSpan int
+}
+
+// Grid represents a container placing its items in the cells of a
+// grid.
+type Grid struct {
+	width, height int
+	x, y          int
+
+	columns, rows      []Track
+	colSizes, rowSizes []int
+	cells              []*cell
+}
+
+// New creates a new Grid with optional configurations, using the
+// default dimensions defined by DefaultWidth and DefaultHeight.
+func New(opts ...Option) *Grid {
+	g := &Grid{width: DefaultWidth, height: DefaultHeight}
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g
+}
+
+// AddItem places an item at the given row and column, spanning
+// rowSpan rows and colSpan columns.
+func (g *Grid) AddItem(item layout.Placeable, row, col, rowSpan, colSpan int) *Grid {
+	if rowSpan < 1 {
+		rowSpan = 1
+	}
+	if colSpan < 1 {
+		colSpan = 1
+	}
+
+	g.cells = append(g.cells, &cell{item, row, col, rowSpan, colSpan})
+
+	return g
+}
+
+// Items returns the items currently in the grid.
+func (g *Grid) Items() []layout.Placeable {
+	items := make([]layout.Placeable, 0)
+
+	for _, c := range g.cells {
+		items = append(items, c.item)
+	}
+
+	return items
+}
+
+// SetSize computes the size of each track and resizes the items
+// accordingly.
+func (g *Grid) SetSize(width int, height int) {
+	g.width = width
+	g.height = height
+
+	g.colSizes = g.solve(width, g.columns, func(c *cell) (int, int, int) {
+		if c.item.CanGrow() {
+			return c.col, c.colSpan, c.item.GetWidth()
+		}
+		return c.col, c.colSpan, lipgloss.Width(c.item.View())
+	})
+	g.rowSizes = g.solve(height, g.rows, func(c *cell) (int, int, int) {
+		if c.item.CanGrow() {
+			return c.row, c.rowSpan, c.item.GetHeight()
+		}
+		return c.row, c.rowSpan, lipgloss.Height(c.item.View())
+	})
+
+	for _, c := range g.cells {
+		c.item.SetSize(
+			sum(g.colSizes, c.col, c.colSpan),
+			sum(g.rowSizes, c.row, c.rowSpan),
+		)
+	}
+
+	layout.PlaceChildren(g, g.x, g.y)
+}
+
+// Placements returns the items with the area assigned to them by the
+// last call to SetSize.
+func (g *Grid) Placements() []layout.Placement {
+	placements := make([]layout.Placement, 0)
+
+	for _, c := range g.cells {
+		placements = append(placements, layout.Placement{
+			Item: c.item,
+			Rect: layout.Rect{
+				X: sum(g.colSizes, 0, c.col),
+				Y: sum(g.rowSizes, 0, c.row),
+				W: sum(g.colSizes, c.col, c.colSpan),
+				H: sum(g.rowSizes, c.row, c.rowSpan),
+			},
+		})
+	}
+
+	return placements
+}
+
+// View returns the string representation of the grid. Each row is
+// rendered by joining horizontally the portion of the cells that
+// falls into it, then rows are joined vertically.
+func (g *Grid) View() string {
+	blocks := make(map[*cell][]string)
+	rows := make([]string, 0)
+
+	for r, rowH := range g.rowSizes {
+		if rowH == 0 {
+			continue
+		}
+
+		strs := make([]string, 0)
+
+		for col := 0; col < len(g.colSizes); {
+			c := g.cellAt(r, col)
+
+			if c == nil {
+				strs = append(strs, block("", g.colSizes[col], rowH))
+				col++
+				continue
+			}
+
+			lines, ok := blocks[c]
+			if !ok {
+				h := sum(g.rowSizes, c.row, c.rowSpan)
+				lines = strings.Split(block(c.item.View(), sum(g.colSizes, c.col, c.colSpan), h), "\n")
+				blocks[c] = lines
+			}
+
+			offset := sum(g.rowSizes, c.row, r-c.row)
+			strs = append(strs, strings.Join(lines[offset:offset+rowH], "\n"))
+			col = c.col + c.colSpan
+		}
+
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, strs...))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+// SetOrigin moves the grid to the absolute position x, y.
+func (g *Grid) SetOrigin(x, y int) {
+	g.x, g.y = x, y
+	layout.PlaceChildren(g, x, y)
+}
+
+// Origin returns the absolute position of the grid.
+func (g *Grid) Origin() (int, int) {
+	return g.x, g.y
+}
+
+// HitTest returns the innermost item drawn at the absolute position
+// x, y, or nil if there is none.
+func (g *Grid) HitTest(x, y int) layout.Placeable {
+	return layout.HitTest(g, x-g.x, y-g.y)
+}
+
+// GetWidth returns the current width of the grid.
+func (g *Grid) GetWidth() int { return g.width }
+
+// GetHeight returns the current height of the grid.
+func (g *Grid) GetHeight() int { return g.height }
+
+func (g *Grid) SetWidth(width int) { g.width = width }
+
+func (g *Grid) SetHeight(height int) { g.height = height }
+
+func (g *Grid) CanGrow() bool {
+	return true
+}
+
+// cellAt returns the cell covering the given row and column, or nil
+// if the position is empty.
+func (g *Grid) cellAt(row, col int) *cell {
+	for _, c := range g.cells {
+		if row >= c.row && row < c.row+c.rowSpan && col >= c.col && col < c.col+c.colSpan {
+			return c
+		}
+	}
+	return nil
+}
+
+// solve computes the size of the given tracks. The measure function
+// returns the first track, the span and the natural size of a cell
+// along the axis being solved. Fixed tracks are sized first, then
+// auto tracks take what they need of the remaining space and
+// fractional tracks share the rest.
+func (g *Grid) solve(total int, tracks []Track, measure func(*cell) (int, int, int)) []int {
+	sizes := make([]int, len(tracks))
+	available := total
+	totalWeight := 0
+	lastFr := -1
+
+	for i, t := range tracks {
+		if t.kind == fixedTrack {
+			sizes[i] = t.size
+			available -= sizes[i]
+		}
+	}
+
+	for i, t := range tracks {
+		switch t.kind {
+		case autoTrack:
+			for _, c := range g.cells {
+				if first, span, size := measure(c); first == i && span == 1 && size > sizes[i] {
+					sizes[i] = size
+				}
+			}
+			sizes[i] = clamp(sizes[i], 0, available)
+			available -= sizes[i]
+		case frTrack:
+			totalWeight += t.size
+			lastFr = i
+		}
+	}
+
+	if available <= 0 || totalWeight == 0 {
+		return sizes
+	}
+
+	used := 0
+
+	for i, t := range tracks {
+		if t.kind == frTrack {
+			sizes[i] = available * t.size / totalWeight
+			used += sizes[i]
+		}
+	}
+
+	sizes[lastFr] += available - used
+
+	return sizes
+}
+
+// sum returns the total size of n tracks starting from first.
+func sum(sizes []int, first, n int) int {
+	total := 0
+
+	for i := first; i < first+n && i < len(sizes); i++ {
+		if i >= 0 {
+			total += sizes[i]
+		}
+	}
+
+	return total
+}
+
+// block renders s as a block of exactly width columns and height
+// lines, truncating or padding it as needed.
+func block(s string, width, height int) string {
+	lines := strings.Split(s, "\n")
+
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+
+	for i, line := range lines {
+		line = ansi.Truncate(line, width, "")
+		if w := ansi.StringWidth(line); w < width {
+			line += strings.Repeat(" ", width-w)
+		}
+		lines[i] = line
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func clamp(v, low, high int) int {
+	if v > high {
+		v = high
+	}
+	if v < low {
+		v = low
+	}
+
+	return v
+}
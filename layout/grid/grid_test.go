@@ -0,0 +1,124 @@
+package grid
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/remogatto/sugarfoam/internal/foamtest"
+	"github.com/remogatto/sugarfoam/layout"
+)
+
+func TestAutoShrink(t *testing.T) {
+	// The growable item was given the whole width by a previous
+	// layout.
+	g := New(
+		WithColumns(Auto(), Fr(1)),
+		WithRows(Fixed(1)),
+		WithItem(&foamtest.Box{Width: 80, Height: 1, Grow: true}, 0, 0),
+		WithItem(&foamtest.Box{Width: 4, Height: 1}, 0, 1),
+	)
+
+	g.SetSize(80, 1)
+	g.SetSize(50, 1)
+
+	for i, size := range g.colSizes {
+		if size < 0 || size > 50 {
+			t.Errorf("column %d is %d cells wide, want 0 to 50", i, size)
+		}
+	}
+	if got := sum(g.colSizes, 0, len(g.colSizes)); got > 50 {
+		t.Errorf("columns take %d cells, want at most 50", got)
+	}
+	if got := lipgloss.Width(g.View()); got != 50 {
+		t.Errorf("view is %d cells wide, want 50", got)
+	}
+}
+
+func TestAutoClamp(t *testing.T) {
+	g := New(
+		WithColumns(Fixed(10), Auto()),
+		WithRows(Fixed(1)),
+		WithItem(&foamtest.Box{Width: 4, Height: 1}, 0, 0),
+		WithItem(&foamtest.Box{Width: 70, Height: 1}, 0, 1),
+	)
+
+	g.SetSize(50, 1)
+
+	if g.colSizes[1] != 40 {
+		t.Errorf("auto column is %d cells wide, want 40", g.colSizes[1])
+	}
+}
+
+func TestAutoGrowable(t *testing.T) {
+	g := New(
+		WithColumns(Auto(), Fr(1)),
+		WithRows(Fixed(1)),
+		WithItem(&foamtest.Box{Width: 12, Height: 1, Grow: true}, 0, 0),
+		WithItem(&foamtest.Box{Width: 4, Height: 1}, 0, 1),
+	)
+
+	g.SetSize(50, 1)
+
+	if g.colSizes[0] != 12 || g.colSizes[1] != 38 {
+		t.Errorf("columns are %v cells wide, want [12 38]", g.colSizes)
+	}
+}
+
+func TestAutoBeforeFixed(t *testing.T) {
+	g := New(
+		WithColumns(Auto(), Fixed(10)),
+		WithRows(Fixed(1)),
+		WithItem(&foamtest.Box{Width: 70, Height: 1}, 0, 0),
+		WithItem(&foamtest.Box{Width: 4, Height: 1}, 0, 1),
+	)
+
+	g.SetSize(50, 1)
+
+	if g.colSizes[0] != 40 || g.colSizes[1] != 10 {
+		t.Errorf("columns are %v cells wide, want [40 10]", g.colSizes)
+	}
+}
+
+func TestFrTracks(t *testing.T) {
+	g := New(WithColumns(Fixed(10), Fr(1), Fr(2)), WithRows(Fr(1)))
+
+	g.SetSize(41, 5)
+
+	if g.colSizes[0] != 10 || g.colSizes[1] != 10 || g.colSizes[2] != 21 {
+		t.Errorf("columns are %v cells wide, want [10 10 21]", g.colSizes)
+	}
+	if g.rowSizes[0] != 5 {
+		t.Errorf("row is %d lines high, want 5", g.rowSizes[0])
+	}
+}
+
+func TestSpan(t *testing.T) {
+	wide := &foamtest.Box{Grow: true}
+	tall := &foamtest.Box{Grow: true}
+
+	g := New(
+		WithColumns(Fr(1), Fr(1), Fr(1)),
+		WithRows(Fixed(2), Fixed(3)),
+		WithSpan(wide, 0, 0, 1, 2),
+		WithSpan(tall, 0, 2, 2, 1),
+		WithItem(&foamtest.Box{Grow: true}, 1, 0),
+	)
+	g.SetSize(30, 5)
+
+	if wide.Width != 20 || wide.Height != 2 {
+		t.Errorf("item spanning two columns is %dx%d, want 20x2", wide.Width, wide.Height)
+	}
+	if tall.Width != 10 || tall.Height != 5 {
+		t.Errorf("item spanning two rows is %dx%d, want 10x5", tall.Width, tall.Height)
+	}
+
+	r, ok := layout.Locate(g, tall)
+	if !ok || r != (layout.Rect{X: 20, Y: 0, W: 10, H: 5}) {
+		t.Errorf("Locate(tall) = %v, %v, want the last column", r, ok)
+	}
+
+	view := g.View()
+	if w, h := lipgloss.Width(view), lipgloss.Height(view); w != 30 || h != 5 {
+		t.Errorf("view is %dx%d, want 30x5", w, h)
+	}
+}
@@ -24,6 +24,16 @@ func WithItem(i Placeable) Option {
 	}
 }
 
+// WithSolver enables the constraint solver to compute the height of
+// the layout items. See Constraints for details. When the
+// constraints can't be satisfied the height is split evenly among
+// the items, and Err reports why.
+func WithSolver(enabled bool) Option {
+	return func(l *Layout) {
+		l.solver = enabled
+	}
+}
+
 // WithStyles sets the styles for the layout container.
 func WithStyles(styles *Styles) Option {
 	return func(l *Layout) {
@@ -51,6 +61,27 @@ type Placeable interface {
 	View() string
 }
 
+// Wrapper is an interface for placeables wrapping another placeable,
+// e.g. to give it sizing constraints.
+type Wrapper interface {
+	// Unwrap returns the wrapped placeable.
+	Unwrap() Placeable
+}
+
+// Unwrap returns the placeable wrapped by item, through any number of
+// wrappers, or item itself if it is not a wrapper. The interfaces
+// implemented by the wrapped placeable, e.g. being focusable, should
+// be checked on the unwrapped item.
+func Unwrap(item Placeable) Placeable {
+	for {
+		w, ok := item.(Wrapper)
+		if !ok {
+			return item
+		}
+		item = w.Unwrap()
+	}
+}
+
 // Styles defines the styles for the layout container.
 type Styles struct {
 	Container lipgloss.Style
@@ -61,6 +92,11 @@ type Layout struct {
 	width, height int
 	items         []Placeable
 	styles        *Styles
+
+	x, y   int
+	rects  []Rect
+	solver bool
+	err    error
 }
 
 // New creates a new Layout with optional configurations.
@@ -99,25 +135,125 @@ func (l *Layout) calcVerticalGrowth(w, h int, items ...Placeable) int {
 		}
 	}
 
+	if nCanGrowItems == 0 || h < staticItemsH {
+		return 0
+	}
+
 	return (h - staticItemsH) / nCanGrowItems
 }
 
+// solve sets the height of the items as computed by the constraint
+// solver. If the constraints can't be satisfied, the height is split
+// evenly among the items and the error is reported by Err.
+func (l *Layout) solve(w, h int) {
+	constraints := make([]Constraints, 0)
+
+	for _, item := range l.items {
+		constraints = append(constraints, constraintsOf(item))
+	}
+
+	sizes, err := Solve(h, constraints...)
+
+	l.err = err
+	if err != nil {
+		sizes = make([]int, len(l.items))
+		all := make([]int, len(l.items))
+		for i := range all {
+			all[i] = i
+		}
+
+		fill(sizes, all, h, func(int) int { return -1 })
+	}
+
+	for i, item := range l.items {
+		if _, ok := item.(Constrained); err == nil && !ok && !item.CanGrow() {
+			item.SetWidth(w)
+			continue
+		}
+		item.SetSize(w, sizes[i])
+	}
+
+	l.arrange(w, sizes)
+}
+
+// arrange records the area assigned to each item, given their
+// heights.
+func (l *Layout) arrange(w int, heights []int) {
+	x, y := FrameOffset(l.styles.Container)
+
+	l.rects = make([]Rect, 0)
+
+	for _, h := range heights {
+		l.rects = append(l.rects, Rect{x, y, w, h})
+		y += h
+	}
+
+	PlaceChildren(l, l.x, l.y)
+}
+
+// Placements returns the items with the area assigned to them by the
+// last call to SetSize, relative to the top-left corner of the
+// layout.
+func (l *Layout) Placements() []Placement {
+	placements := make([]Placement, 0)
+
+	for i, r := range l.rects {
+		placements = append(placements, Placement{l.items[i], r})
+	}
+
+	return placements
+}
+
+// SetOrigin moves the layout to the absolute position x, y.
+func (l *Layout) SetOrigin(x, y int) {
+	l.x, l.y = x, y
+	PlaceChildren(l, x, y)
+}
+
+// Origin returns the absolute position of the layout.
+func (l *Layout) Origin() (int, int) {
+	return l.x, l.y
+}
+
+// HitTest returns the innermost item drawn at the absolute position
+// x, y, or nil if there is none.
+func (l *Layout) HitTest(x, y int) Placeable {
+	return HitTest(l, x-l.x, y-l.y)
+}
+
+// Err returns the error occurred while solving the constraints during
+// the last call to SetSize, if any.
+func (l *Layout) Err() error {
+	return l.err
+}
+
 // SetSize resizes the layout and its items.
 func (l *Layout) SetSize(width int, height int) {
 	w := width - l.styles.Container.GetHorizontalFrameSize()
 	h := height - l.styles.Container.GetVerticalFrameSize()
 
+	if l.solver {
+		l.solve(w, h)
+		return
+	}
+
 	for _, item := range l.items {
 		item.SetWidth(w)
 	}
 
 	canGrowHeight := l.calcVerticalGrowth(w, h, l.items...)
+	heights := make([]int, 0)
 
 	for _, item := range l.items {
 		if item.CanGrow() {
 			item.SetSize(w, canGrowHeight)
+			heights = append(heights, canGrowHeight)
+		} else {
+			heights = append(heights, item.GetHeight())
 		}
 	}
+
+	l.arrange(w, heights)
 }
 
 // View returns the string representation of the layout, rendered with its container styles.
@@ -0,0 +1,103 @@
+package layout
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/remogatto/sugarfoam/internal/foamtest"
+)
+
+func TestHitTestConstrained(t *testing.T) {
+	top := &foamtest.Box{Grow: true}
+	bottom := &foamtest.Box{Grow: true}
+	inner := New(WithStyles(&Styles{}), WithItem(top), WithItem(bottom))
+
+	header := &foamtest.Box{Height: 2}
+	root := New(
+		WithStyles(&Styles{}),
+		WithSolver(true),
+		WithItem(header),
+		WithItem(Constrain(inner, Constraints{Min: 4})),
+	)
+	root.SetSize(10, 12)
+
+	if err := root.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := root.HitTest(3, 1); got != header {
+		t.Errorf("HitTest(3, 1) = %v, want the header", got)
+	}
+	if got := root.HitTest(3, 2); got != top {
+		t.Errorf("HitTest(3, 2) = %v, want the top item of the constrained layout", got)
+	}
+	if got := root.HitTest(3, 11); got != bottom {
+		t.Errorf("HitTest(3, 11) = %v, want the bottom item of the constrained layout", got)
+	}
+
+	r, ok := Locate(root, bottom)
+	if !ok || r.Y != 7 || r.H != 5 {
+		t.Errorf("Locate(bottom) = %v, %v, want an area of height 5 at line 7", r, ok)
+	}
+}
+
+func TestSolverFallback(t *testing.T) {
+	top := &foamtest.Box{Grow: true}
+	bottom := &foamtest.Box{Grow: true}
+	root := New(
+		WithStyles(&Styles{}),
+		WithSolver(true),
+		WithItem(Constrain(top, Constraints{Min: 10})),
+		WithItem(Constrain(bottom, Constraints{Min: 10})),
+	)
+	root.SetSize(10, 12)
+
+	if root.Err() == nil {
+		t.Error("the unsatisfiable constraints are not reported")
+	}
+	if top.Height != 6 || bottom.Height != 6 {
+		t.Errorf("heights are %d and %d, want an even split", top.Height, bottom.Height)
+	}
+
+	r, ok := Locate(root, bottom)
+	if !ok || r.Y != 6 || r.H != 6 {
+		t.Errorf("Locate(bottom) = %v, %v, want an area of height 6 at line 6", r, ok)
+	}
+}
+
+func TestConstrainUnwrap(t *testing.T) {
+	item := &foamtest.Box{}
+
+	if got := Unwrap(Constrain(item, Constraints{Min: 1})); got != item {
+		t.Errorf("Unwrap returned %v, want the constrained item", got)
+	}
+}
+
+func TestGeometryFrame(t *testing.T) {
+	top := &foamtest.Box{Grow: true}
+	bottom := &foamtest.Box{Grow: true}
+	root := New(
+		WithStyles(&Styles{Container: lipgloss.NewStyle().Border(lipgloss.NormalBorder()).Padding(1, 2)}),
+		WithItem(top),
+		WithItem(bottom),
+	)
+	root.SetSize(20, 12)
+	root.SetOrigin(5, 3)
+
+	want := []Rect{{X: 8, Y: 5, W: 14, H: 4}, {X: 8, Y: 9, W: 14, H: 4}}
+	for i, p := range Absolute(root) {
+		if p.Rect != want[i] {
+			t.Errorf("absolute area of item %d = %v, want %v", i, p.Rect, want[i])
+		}
+	}
+
+	if got := root.HitTest(8, 5); got != top {
+		t.Errorf("HitTest(8, 5) = %v, want the top item", got)
+	}
+	if got := root.HitTest(21, 12); got != bottom {
+		t.Errorf("HitTest(21, 12) = %v, want the bottom item", got)
+	}
+	if got := root.HitTest(5, 3); got != nil {
+		t.Errorf("HitTest(5, 3) = %v, want nothing on the border", got)
+	}
+}
@@ -0,0 +1,281 @@
+package overlay
+
+// Package overlay provides a way to draw placeables (dialogs,
+// dropdowns, toasts...) on top of a rendered base view. Layers are
+// composited line by line over the base, taking care of ANSI escape
+// sequences and wide characters so that the parts of the base that
+// remain visible keep their styles.
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/remogatto/sugarfoam/layout"
+)
+
+// Position computes the top-left corner of a layer of the given size
+// drawn over base, whose rendered view spans bounds.
+type Position func(base layout.Placeable, bounds layout.Rect, width, height int) (int, int)
+
+// At places the layer at the absolute position x, y.
+func At(x, y int) Position {
+	return func(_ layout.Placeable, _ layout.Rect, _, _ int) (int, int) {
+		return x, y
+	}
+}
+
+// Align places the layer relative to the edges of the base view,
+// e.g. Align(lipgloss.Right, lipgloss.Bottom) puts it in the
+// bottom-right corner.
+func Align(h, v lipgloss.Position) Position {
+	return func(_ layout.Placeable, bounds layout.Rect, width, height int) (int, int) {
+		x := bounds.X + int(float64(bounds.W-width)*float64(h))
+		y := bounds.Y + int(float64(bounds.H-height)*float64(v))
+
+		return x, y
+	}
+}
+
+// Center places the layer at the center of the base view.
+func Center() Position {
+	return Align(lipgloss.Center, lipgloss.Center)
+}
+
+// Offset moves the layer placed by p by dx, dy.
+func Offset(p Position, dx, dy int) Position {
+	return func(base layout.Placeable, bounds layout.Rect, width, height int) (int, int) {
+		x, y := p(base, bounds, width, height)
+		return x + dx, y + dy
+	}
+}
+
+// Side defines where a layer is anchored with respect to an item.
+type Side int
+
+const (
+	Below Side = iota
+	Above
+	Left
+	Right
+	Over
+)
+
+// Anchor places the layer next to item, on the given side. Item must
+// be reachable from the base through layout.Locate, otherwise the
+// layer is centered.
+func Anchor(item layout.Placeable, side Side) Position {
+	return func(base layout.Placeable, bounds layout.Rect, width, height int) (int, int) {
+		r, ok := layout.Locate(base, item)
+		if !ok {
+			return Center()(base, bounds, width, height)
+		}
+
+		switch side {
+		case Above:
+			return r.X, r.Y - height
+		case Left:
+			return r.X - width, r.Y
+		case Right:
+			return r.X + r.W, r.Y
+		case Over:
+			return r.X, r.Y
+		}
+
+		return r.X, r.Y + r.H
+	}
+}
+
+// Option is a type for functions that modify an overlay model.
+type Option func(*Model)
+
+// WithLayer adds a layer placed at the given position.
+func WithLayer(item layout.Placeable, position Position) Option {
+	return func(m *Model) {
+		m.Push(item, position)
+	}
+}
+
+type layer struct {
+	item     layout.Placeable
+	position Position
+}
+
+// Model draws a stack of layers over a base placeable. The model is
+// itself a placeable, so it is usually the root of the document.
+type Model struct {
+	width, height int
+
+	base   layout.Placeable
+	layers []*layer
+}
+
+// New creates a new overlay model drawing its layers over base.
+func New(base layout.Placeable, opts ...Option) *Model {
+	m := &Model{
+		base:   base,
+		width:  layout.DefaultWidth,
+		height: layout.DefaultHeight,
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Push adds a layer on top of the others.
+func (m *Model) Push(item layout.Placeable, position Position) {
+	m.layers = append(m.layers, &layer{item, position})
+}
+
+// Pop removes the topmost layer and returns its item, or nil if there
+// are no layers.
+func (m *Model) Pop() layout.Placeable {
+	if len(m.layers) == 0 {
+		return nil
+	}
+
+	top := m.layers[len(m.layers)-1]
+	m.layers = m.layers[:len(m.layers)-1]
+
+	return top.item
+}
+
+// Remove removes the layer showing item, if any.
+func (m *Model) Remove(item layout.Placeable) {
+	for i, l := range m.layers {
+		if l.item == item {
+			m.layers = append(m.layers[:i], m.layers[i+1:]...)
+			return
+		}
+	}
+}
+
+// Top returns the item of the topmost layer, or nil if there are no
+// layers.
+func (m *Model) Top() layout.Placeable {
+	if len(m.layers) == 0 {
+		return nil
+	}
+	return m.layers[len(m.layers)-1].item
+}
+
+// Layers returns the items of the layers, from the bottom to the top.
+func (m *Model) Layers() []layout.Placeable {
+	items := make([]layout.Placeable, 0)
+
+	for _, l := range m.layers {
+		items = append(items, l.item)
+	}
+
+	return items
+}
+
+// Base returns the placeable the layers are drawn over.
+func (m *Model) Base() layout.Placeable {
+	return m.base
+}
+
+// SetSize sets the dimensions of the overlay and of its base. Layers
+// keep their own size.
+func (m *Model) SetSize(width int, height int) {
+	m.width = width
+	m.height = height
+
+	m.base.SetSize(width, height)
+}
+
+// View renders the base and composites the layers over it, from the
+// bottom to the top.
+func (m *Model) View() string {
+	view := m.base.View()
+	bounds := layout.Rect{X: 0, Y: 0, W: lipgloss.Width(view), H: lipgloss.Height(view)}
+
+	for _, l := range m.layers {
+		fg := l.item.View()
+		x, y := l.position(m.base, bounds, lipgloss.Width(fg), lipgloss.Height(fg))
+		view = Composite(view, fg, x, y)
+	}
+
+	return view
+}
+
+// GetWidth returns the current width of the overlay.
+func (m *Model) GetWidth() int { return m.width }
+
+// GetHeight returns the current height of the overlay.
+func (m *Model) GetHeight() int { return m.height }
+
+func (m *Model) SetWidth(width int) { m.width = width }
+
+func (m *Model) SetHeight(height int) { m.height = height }
+
+func (m *Model) CanGrow() bool {
+	return true
+}
+
+// Composite draws fg over base with its top-left corner at x, y and
+// returns the result. The parts of fg falling outside base are
+// clipped.
+func Composite(base, fg string, x, y int) string {
+	baseLines := strings.Split(base, "\n")
+	fgLines := strings.Split(fg, "\n")
+	width := lipgloss.Width(fg)
+	baseWidth := lipgloss.Width(base)
+
+	for i, line := range fgLines {
+		row := y + i
+		if row < 0 || row >= len(baseLines) {
+			continue
+		}
+		baseLines[row] = splice(baseLines[row], line, x, width, baseWidth)
+	}
+
+	return strings.Join(baseLines, "\n")
+}
+
+// splice replaces the cells of line from x to x+width with fg,
+// padding fg to width. The cells of fg past maxWidth are clipped.
+func splice(line, fg string, x, width, maxWidth int) string {
+	if x < 0 {
+		fg = ansi.TruncateLeft(fg, -x, "")
+		width += x
+		x = 0
+	}
+
+	if x+width > maxWidth {
+		width = maxWidth - x
+		fg = ansi.Truncate(fg, width, "")
+	}
+
+	if width <= 0 {
+		return line
+	}
+
+	if w := ansi.StringWidth(fg); w < width {
+		fg += strings.Repeat(" ", width-w)
+	}
+
+	// A wide character cut by the left edge of fg is dropped by
+	// Truncate and replaced with padding.
+	left := ansi.Truncate(line, x, "")
+	if w := ansi.StringWidth(left); w < x {
+		left += strings.Repeat(" ", x-w)
+	}
+
+	var right string
+
+	lineW := ansi.StringWidth(line)
+	if end := x + width; lineW > end {
+		right = ansi.TruncateLeft(line, end, "")
+
+		// A wide character cut by the right edge of fg is kept
+		// whole by TruncateLeft, so it's replaced with a space.
+		if ansi.StringWidth(right) > lineW-end {
+			right = ansi.TruncateLeft(line, end+1, " ")
+		}
+	}
+
+	return left + ansi.ResetStyle + fg + ansi.ResetStyle + right
+}
@@ -0,0 +1,70 @@
+package overlay
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestCompositeClipsRightEdge(t *testing.T) {
+	base := strings.Repeat(".", 10) + "\n" + strings.Repeat(".", 10)
+
+	got := strings.Split(Composite(base, "abcd\nefgh", 8, 0), "\n")
+	want := []string{"........ab", "........ef"}
+
+	for i, line := range got {
+		if line = ansi.Strip(line); line != want[i] {
+			t.Errorf("line %d = %q, want %q", i, line, want[i])
+		}
+	}
+
+	got = strings.Split(Composite(base, "abcd", 12, 1), "\n")
+
+	if line := ansi.Strip(got[1]); line != strings.Repeat(".", 10) {
+		t.Errorf("layer past the right edge drawn as %q", line)
+	}
+}
+
+func TestCompositeWideRunes(t *testing.T) {
+	base := "ab世界cd"
+
+	for _, tc := range []struct {
+		x    int
+		want string
+	}{
+		{2, "abX 界cd"}, // the layer covers the left half of 世
+		{3, "ab X界cd"}, // the layer covers the right half of 世
+		{5, "ab世 Xcd"}, // the layer covers the right half of 界
+	} {
+		got := Composite(base, "X", tc.x, 0)
+
+		if line := ansi.Strip(got); line != tc.want {
+			t.Errorf("layer at %d drawn as %q, want %q", tc.x, line, tc.want)
+		}
+		if w := ansi.StringWidth(got); w != 8 {
+			t.Errorf("layer at %d makes the line %d cells wide, want 8", tc.x, w)
+		}
+	}
+}
+
+func TestCompositeStyledBase(t *testing.T) {
+	red := "\x1b[31m"
+	base := red + "redred" + ansi.ResetStyle + "...."
+
+	got := Composite(base, "X", 2, 0)
+
+	if line := ansi.Strip(got); line != "reXred...." {
+		t.Fatalf("layer drawn as %q, want %q", line, "reXred....")
+	}
+
+	// The layer is not drawn with the style of the base line, which
+	// is restored after it.
+	i := strings.Index(got, "X")
+	if !strings.HasSuffix(got[:i], ansi.ResetStyle) {
+		t.Errorf("layer is not preceded by a style reset in %q", got)
+	}
+	if !strings.HasPrefix(got[i+1:], ansi.ResetStyle+red+"red") {
+		t.Errorf("style of the base line is not restored after the layer in %q", got)
+	}
+}
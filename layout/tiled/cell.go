@@ -0,0 +1,188 @@
+package tiled
+
+import (
+	"github.com/remogatto/sugarfoam/layout"
+)
+
+// Cell wraps a placeable item with the constraints a tile uses to
+// size it along its main axis (the width for a HorizontalTile, the
+// height for a VerticalTile). Cells are created with Weight, Fixed,
+// Min and Max, which can be nested to combine constraints, e.g.
+//
+//	tiled.New(tiled.Fixed(30, sidebar), tiled.Min(20, tiled.Weight(2, viewport)))
+//
+// A cell is either fixed or weighted: when Fixed and Weight are
+// nested, the outer one wins. Min and Max only apply to weighted
+// cells. The wrapped item is returned by Unwrap.
+type Cell struct {
+	layout.Placeable
+
+	weight        int
+	fixed         int
+	min, max      int
+	width, height int
+}
+
+// Weight sets the relative weight of the item, making the cell
+// weighted even if it was fixed. The space not taken by fixed cells
+// is split among the other cells proportionally to their weights.
+// Items that are not wrapped in a cell have a weight of 1.
+func Weight(weight int, item layout.Placeable) *Cell {
+	c := cellOf(item)
+	c.weight = weight
+	c.fixed = -1
+
+	return c
+}
+
+// Fixed gives the item a fixed size along the main axis of the tile,
+// making the cell fixed even if it was weighted. Fixed cells are
+// sized first, in order, each within the space left by the previous
+// ones.
+func Fixed(size int, item layout.Placeable) *Cell {
+	c := cellOf(item)
+	c.fixed = size
+
+	return c
+}
+
+// Min sets the minimum size of a weighted item.
+func Min(size int, item layout.Placeable) *Cell {
+	c := cellOf(item)
+	c.min = size
+
+	return c
+}
+
+// Max sets the maximum size of a weighted item. A value of zero
+// means no maximum.
+func Max(size int, item layout.Placeable) *Cell {
+	c := cellOf(item)
+	c.max = size
+
+	return c
+}
+
+// SetSize sets the dimensions of the cell and of the wrapped item.
+func (c *Cell) SetSize(width int, height int) {
+	c.width = width
+	c.height = height
+
+	c.Placeable.SetSize(width, height)
+}
+
+// Unwrap returns the wrapped item.
+func (c *Cell) Unwrap() layout.Placeable {
+	return c.Placeable
+}
+
+// Placements returns the wrapped item, which takes the whole cell.
+func (c *Cell) Placements() []layout.Placement {
+	return []layout.Placement{{Item: c.Placeable, Rect: layout.Rect{X: 0, Y: 0, W: c.width, H: c.height}}}
+}
+
+func cellOf(item layout.Placeable) *Cell {
+	if c, ok := item.(*Cell); ok {
+		return c
+	}
+	return &Cell{Placeable: item, weight: 1, fixed: -1}
+}
+
+func (c *Cell) span() span {
+	return span{weight: c.weight, fixed: c.fixed, min: c.min, max: c.max}
+}
+
+// span holds the sizing constraints of a single item along the main
+// axis of a tile. A negative fixed size means the item is weighted.
+type span struct {
+	weight, fixed, min, max int
+}
+
+// distribute splits total among the given spans. Fixed spans get
+// their size, as far as it fits in the space left by the previous
+// fixed spans, the rest is split among weighted spans proportionally
+// to their weights and clamped to their min/max constraints. The
+// remainder of the integer division goes to the last weighted span
+// that is not clamped.
+func distribute(total int, spans []span) []int {
+	sizes := make([]int, len(spans))
+	frozen := make([]bool, len(spans))
+
+	available := total
+
+	for i, s := range spans {
+		if s.fixed >= 0 {
+			sizes[i] = s.fixed
+			if sizes[i] > available {
+				sizes[i] = available
+			}
+			frozen[i] = true
+			available -= sizes[i]
+		}
+	}
+
+	for {
+		if available < 0 {
+			available = 0
+		}
+
+		var totalWeight int
+
+		for i, s := range spans {
+			if !frozen[i] && s.weight > 0 {
+				totalWeight += s.weight
+			}
+		}
+
+		clamped := make([]int, 0)
+
+		for i, s := range spans {
+			if frozen[i] {
+				continue
+			}
+
+			share := 0
+			if totalWeight > 0 && s.weight > 0 {
+				share = available * s.weight / totalWeight
+			}
+
+			switch {
+			case share < s.min:
+				sizes[i] = s.min
+				clamped = append(clamped, i)
+			case s.max > 0 && share > s.max:
+				sizes[i] = s.max
+				clamped = append(clamped, i)
+			default:
+				sizes[i] = share
+			}
+		}
+
+		if len(clamped) == 0 {
+			break
+		}
+
+		for _, i := range clamped {
+			frozen[i] = true
+			available -= sizes[i]
+		}
+	}
+
+	last := -1
+	used := 0
+
+	for i, s := range spans {
+		if !frozen[i] {
+			used += sizes[i]
+			if s.weight > 0 {
+				last = i
+			}
+		}
+	}
+
+	if last >= 0 && available > used {
+		sizes[last] += available - used
+	}
+
+	return sizes
+}
@@ -1,18 +1,18 @@
 package tiled
 
 // Package tiled provides utilities for creating and managing
-// horizontal tiles in a UI.  It leverages the Lipgloss library for
-// styling and the Sugarfoam layout management system for positioning
-// and sizing components. The package is designed to facilitate the
-// creation of horizontally aligned layouts with a focus on simplicity
-// and ease of use.
+// horizontal and vertical tiles in a UI.  It leverages the Lipgloss
+// library for styling and the Sugarfoam layout management system for
+// positioning and sizing components. The package is designed to
+// facilitate the creation of horizontally or vertically aligned
+// layouts with a focus on simplicity and ease of use.
 import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/remogatto/sugarfoam/layout"
 )
 
 // DefaultWidth and DefaultHeight define the default dimensions for a
-// horizontal tiled layout.
+// tiled layout.
 var (
 	DefaultWidth  = 80
 	DefaultHeight = 25
@@ -22,14 +22,13 @@ var (
 // placeable items.
 type HorizontalTile struct {
 	width, height int
+	x, y          int
 	items         []layout.Placeable
+	rects         []layout.Rect
 }
 
 // View returns the string representation of the horizontal tile, with
-// all its items rendered horizontally. The width of each item is
-// evenly distributed, with the last item potentially taking up the
-// remaining space if the total width is not evenly divisible by the
-// number of items.
+// all its items rendered horizontally.
 func (ht *HorizontalTile) View() string {
 	strs := make([]string, 0)
 
@@ -40,22 +39,66 @@ func (ht *HorizontalTile) View() string {
 	return lipgloss.JoinHorizontal(lipgloss.Top, strs...)
 }
 
-// SetSize sets the dimensions of the horizontal tile.
+// SetSize sets the dimensions of the horizontal tile. The width of
+// each item is distributed according to its cell constraints (see
+// Weight, Fixed, Min and Max). Items not wrapped in a cell share the
+// width evenly, with the last item potentially taking up the
+// remaining space if the total width is not evenly divisible by the
+// number of items.
 func (ht *HorizontalTile) SetSize(width int, height int) {
 	ht.width = width
 	ht.height = height
 
-	w := ht.width / len(ht.items)
-	dw := ht.width - w*len(ht.items)
+	spans := make([]span, len(ht.items))
 
 	for i, item := range ht.items {
-		if i == len(ht.items)-1 {
-			item.SetSize(w+dw, height)
-		} else {
-			item.SetSize(w, height)
-		}
+		spans[i] = cellOf(item).span()
+	}
+
+	ht.rects = make([]layout.Rect, 0)
+	x := 0
+
+	for i, w := range distribute(width, spans) {
+		ht.items[i].SetSize(w, height)
+		ht.rects = append(ht.rects, layout.Rect{X: x, Y: 0, W: w, H: height})
+		x += w
+	}
+
+	layout.PlaceChildren(ht, ht.x, ht.y)
+}
+
+// Items returns the items currently in the horizontal tile.
+func (ht *HorizontalTile) Items() []layout.Placeable {
+	return ht.items
+}
+
+// Placements returns the items with the area assigned to them by the
+// last call to SetSize.
+func (ht *HorizontalTile) Placements() []layout.Placement {
+	placements := make([]layout.Placement, 0)
+
+	for i, r := range ht.rects {
+		placements = append(placements, layout.Placement{Item: ht.items[i], Rect: r})
 	}
 
+	return placements
+}
+
+// SetOrigin moves the horizontal tile to the absolute position x, y.
+func (ht *HorizontalTile) SetOrigin(x, y int) {
+	ht.x, ht.y = x, y
+	layout.PlaceChildren(ht, x, y)
+}
+
+// Origin returns the absolute position of the horizontal tile.
+func (ht *HorizontalTile) Origin() (int, int) {
+	return ht.x, ht.y
+}
+
+// HitTest returns the innermost item drawn at the absolute position
+// x, y, or nil if there is none.
+func (ht *HorizontalTile) HitTest(x, y int) layout.Placeable {
+	return layout.HitTest(ht, x-ht.x, y-ht.y)
 }
 
 // GetWidth returns the current width of the horizontal tile.
@@ -75,5 +118,5 @@ func (ht *HorizontalTile) CanGrow() bool {
 // New creates a new HorizontalTile with the specified items, using
 // the default dimensions defined by DefaultWidth and DefaultHeight.
 func New(items ...layout.Placeable) *HorizontalTile {
-	return &HorizontalTile{DefaultWidth, DefaultHeight, items}
+	return &HorizontalTile{width: DefaultWidth, height: DefaultHeight, items: items}
 }
@@ -0,0 +1,50 @@
+package tiled
+
+import (
+	"testing"
+
+	"github.com/remogatto/sugarfoam/internal/foamtest"
+	"github.com/remogatto/sugarfoam/layout"
+)
+
+func widths(items ...*foamtest.Box) []int {
+	w := make([]int, len(items))
+	for i, item := range items {
+		w[i] = item.Width
+	}
+	return w
+}
+
+func TestFixedClamp(t *testing.T) {
+	a, b, c := &foamtest.Box{Grow: true}, &foamtest.Box{Grow: true}, &foamtest.Box{Grow: true}
+
+	New(Fixed(30, a), Fixed(30, b), c).SetSize(40, 1)
+
+	if got := widths(a, b, c); got[0] != 30 || got[1] != 10 || got[2] != 0 {
+		t.Errorf("widths are %v, want [30 10 0]", got)
+	}
+}
+
+func TestFixedWeightOuterWins(t *testing.T) {
+	a, b := &foamtest.Box{Grow: true}, &foamtest.Box{Grow: true}
+
+	New(Weight(1, Fixed(30, a)), b).SetSize(40, 1)
+
+	if got := widths(a, b); got[0] != 20 || got[1] != 20 {
+		t.Errorf("widths are %v, want [20 20]", got)
+	}
+
+	New(Fixed(30, Weight(1, a)), b).SetSize(40, 1)
+
+	if got := widths(a, b); got[0] != 30 || got[1] != 10 {
+		t.Errorf("widths are %v, want [30 10]", got)
+	}
+}
+
+func TestCellUnwrap(t *testing.T) {
+	field := foamtest.NewField("field")
+
+	if got := layout.Unwrap(Min(2, Weight(2, field))); got != field {
+		t.Errorf("Unwrap returned %v, want the wrapped field", got)
+	}
+}
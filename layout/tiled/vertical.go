@@ -0,0 +1,121 @@
+package tiled
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/remogatto/sugarfoam/layout"
+)
+
+// VerticalTile represents a container for vertically stacked
+// placeable items.
+type VerticalTile struct {
+	width, height int
+	x, y          int
+	items         []layout.Placeable
+	rects         []layout.Rect
+}
+
+// View returns the string representation of the vertical tile, with
+// all its items rendered one below the other.
+func (vt *VerticalTile) View() string {
+	strs := make([]string, 0)
+
+	for _, item := range vt.items {
+		strs = append(strs, item.View())
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, strs...)
+}
+
+// SetSize sets the dimensions of the vertical tile. Items that can't
+// grow keep their own height, while the remaining height is
+// distributed among the items that can grow according to their cell
+// constraints (see Weight, Fixed, Min and Max). Items not wrapped in
+// a cell share it evenly, with the last of them taking up the
+// remaining space if the height is not evenly divisible by their
+// number.
+func (vt *VerticalTile) SetSize(width int, height int) {
+	vt.width = width
+	vt.height = height
+
+	spans := make([]span, len(vt.items))
+
+	for i, item := range vt.items {
+		if _, ok := item.(*Cell); !ok && !item.CanGrow() {
+			item.SetWidth(width)
+			spans[i] = span{fixed: item.GetHeight()}
+			continue
+		}
+		spans[i] = cellOf(item).span()
+	}
+
+	vt.rects = make([]layout.Rect, 0)
+	y := 0
+
+	for i, h := range distribute(height, spans) {
+		vt.rects = append(vt.rects, layout.Rect{X: 0, Y: y, W: width, H: h})
+		y += h
+
+		item := vt.items[i]
+		if _, ok := item.(*Cell); !ok && !item.CanGrow() {
+			continue
+		}
+		item.SetSize(width, h)
+	}
+
+	layout.PlaceChildren(vt, vt.x, vt.y)
+}
+
+// Items returns the items currently in the vertical tile.
+func (vt *VerticalTile) Items() []layout.Placeable {
+	return vt.items
+}
+
+// Placements returns the items with the area assigned to them by the
+// last call to SetSize.
+func (vt *VerticalTile) Placements() []layout.Placement {
+	placements := make([]layout.Placement, 0)
+
+	for i, r := range vt.rects {
+		placements = append(placements, layout.Placement{Item: vt.items[i], Rect: r})
+	}
+
+	return placements
+}
+
+// SetOrigin moves the vertical tile to the absolute position x, y.
+func (vt *VerticalTile) SetOrigin(x, y int) {
+	vt.x, vt.y = x, y
+	layout.PlaceChildren(vt, x, y)
+}
+
+// Origin returns the absolute position of the vertical tile.
+func (vt *VerticalTile) Origin() (int, int) {
+	return vt.x, vt.y
+}
+
+// HitTest returns the innermost item drawn at the absolute position
+// x, y, or nil if there is none.
+func (vt *VerticalTile) HitTest(x, y int) layout.Placeable {
+	return layout.HitTest(vt, x-vt.x, y-vt.y)
+}
+
+// GetWidth returns the current width of the vertical tile.
+func (vt *VerticalTile) GetWidth() int { return vt.width }
+
+// GetHeight returns the current height of the vertical tile.
+func (vt *VerticalTile) GetHeight() int { return vt.height }
+
+func (vt *VerticalTile) SetWidth(width int) { vt.width = width }
+
+func (vt *VerticalTile) SetHeight(height int) { vt.height = height }
+
+func (vt *VerticalTile) CanGrow() bool {
+	return true
+}
+
+// NewVertical creates a new VerticalTile with the specified items,
+// using the default dimensions defined by DefaultWidth and
+// DefaultHeight.
+func NewVertical(items ...layout.Placeable) *VerticalTile {
+	return &VerticalTile{width: DefaultWidth, height: DefaultHeight, items: items}
+}
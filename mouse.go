@@ -0,0 +1,27 @@
+package foam
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/remogatto/sugarfoam/layout"
+)
+
+// LocalMouse returns msg with its coordinates made relative to the
+// top-left corner of r, the area of the component the message is
+// dispatched to.
+//
+// Components expect mouse messages in their own coordinates:
+// containers translate them with LocalMouse before passing them to
+// the element under the pointer, and the application does the same
+// for the root component, e.g. using the area returned by
+// layout.Locate.
+func LocalMouse(msg tea.MouseMsg, r layout.Rect) tea.MouseMsg {
+	msg.X -= r.X
+	msg.Y -= r.Y
+
+	return msg
+}
+
+// IsClick returns true if msg is a press of the left mouse button.
+func IsClick(msg tea.MouseMsg) bool {
+	return msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft
+}
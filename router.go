@@ -0,0 +1,55 @@
+package foam
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Route tells a container which of its elements a message is
+// dispatched to.
+type Route int
+
+const (
+	// Broadcast dispatches the message to all the elements.
+	Broadcast Route = iota
+
+	// Focused dispatches the message to the current element only.
+	Focused
+
+	// Pointer dispatches a mouse message to the element under the
+	// pointer only. Other messages are dispatched as Focused.
+	Pointer
+)
+
+// Router decides the route of each message dispatched by a
+// container.
+type Router func(msg tea.Msg) Route
+
+// Routed is an interface for messages choosing their own route.
+type Routed interface {
+	Route() Route
+}
+
+// DefaultRouter routes key messages to the focused path, mouse
+// messages to the element under the pointer and broadcasts any other
+// message, e.g. ticks, window size changes and async results.
+// Messages implementing Routed are routed as they ask.
+//
+// Custom routers can handle their own message classes and fall back
+// to DefaultRouter for the others.
+func DefaultRouter(msg tea.Msg) Route {
+	switch msg := msg.(type) {
+	case Routed:
+		return msg.Route()
+	case tea.KeyMsg:
+		return Focused
+	case tea.MouseMsg:
+		return Pointer
+	}
+
+	return Broadcast
+}
+
+// BroadcastRouter dispatches every message to all the elements.
+func BroadcastRouter(msg tea.Msg) Route {
+	return Broadcast
+}